@@ -0,0 +1,41 @@
+package common
+
+import (
+	"github.com/xabierlaiseca/gowrap/pkg/config"
+	"github.com/xabierlaiseca/gowrap/pkg/project"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/versions"
+)
+
+// AutoInstallVersionIfConfigured installs the latest available version for the
+// defined version if required by the autoinstall configuration. It returns the
+// installed version, or an empty string if no version was installed.
+func AutoInstallVersionIfConfigured(gowrapHome string, version *project.Version) (string, error) {
+	c, err := config.Load(gowrapHome)
+	if err != nil {
+		return "", err
+	}
+
+	// development versions can only be built from source
+	if semver.IsDevel(version.Defined) || c.AutoInstall == config.AutoInstallDisabled ||
+		(c.AutoInstall == config.AutoInstallMissing && version.IsAvailable()) {
+		return "", nil
+	}
+
+	candidate, err := versions.FindLatestAvailable(gowrapHome, version.Defined)
+	if err != nil {
+		return "", err
+	}
+
+	if !semver.IsLessThan(version.Installed, candidate) {
+		return "", nil
+	}
+
+	if installed, err := versions.InstallIfNotInstalled(gowrapHome, candidate); err != nil {
+		return "", err
+	} else if installed {
+		autoPruneIfConfigured(gowrapHome, c, candidate)
+	}
+
+	return candidate, nil
+}
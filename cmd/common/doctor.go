@@ -0,0 +1,235 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/xabierlaiseca/gowrap/pkg/cache"
+	"github.com/xabierlaiseca/gowrap/pkg/config"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/versions"
+)
+
+// WrapperVersionEnvVar makes wrapper commands print their gowrap version
+// instead of running the wrapped command when set.
+const WrapperVersionEnvVar = "GOWRAP_WRAPPER_VERSION"
+
+const wrapperVersionTimeout = 10 * time.Second
+
+// WrappedCommands are the go commands gowrap provides wrappers for.
+var WrappedCommands = []string{"go", "gofmt"}
+
+// Finding is a problem found in the gowrap installation.
+type Finding struct {
+	Problem string
+	// Advice describes how to solve the problem.
+	Advice string
+	// Fix solves the problem, nil if it can't be solved automatically.
+	Fix func() error
+}
+
+// Diagnose checks the gowrap installation and returns the problems found.
+func Diagnose(gowrapHome, gowrapVersion string) []Finding {
+	var findings []Finding
+	findings = append(findings, checkConfig(gowrapHome)...)
+	findings = append(findings, checkCacheMetadata()...)
+	findings = append(findings, checkInstalledVersions(gowrapHome)...)
+
+	executable, err := os.Executable()
+	if err == nil {
+		executable, err = filepath.EvalSymlinks(executable)
+	}
+	if err != nil {
+		return append(findings, Finding{
+			Problem: fmt.Sprintf("failed to find the gowrap executable: %v", err),
+			Advice:  "reinstall gowrap",
+		})
+	}
+
+	binariesDir := filepath.Dir(executable)
+	findings = append(findings, checkPath(binariesDir, os.Getenv(PathEnvVar))...)
+	findings = append(findings, checkWrappers(binariesDir, gowrapVersion)...)
+	return findings
+}
+
+func checkConfig(gowrapHome string) []Finding {
+	c, err := config.Load(gowrapHome)
+	if err == nil {
+		err = c.Validate()
+	}
+	if err == nil {
+		return nil
+	}
+
+	return []Finding{{
+		Problem: fmt.Sprintf("invalid configuration: %v", err),
+		Advice:  "fix it with 'gowrap configure' or reset it to defaults with 'gowrap doctor --fix', which keeps a backup of the current one",
+		Fix: func() error {
+			_, err := config.Reset(gowrapHome)
+			return err
+		},
+	}}
+}
+
+func checkCacheMetadata() []Finding {
+	err := cache.CheckMetadata()
+	if err == nil {
+		return nil
+	}
+
+	return []Finding{{
+		Problem: fmt.Sprintf("broken cache metadata: %v", err),
+		Advice:  "rebuild it with 'gowrap doctor --fix', cached go archives are kept",
+		Fix:     cache.ResetMetadata,
+	}}
+}
+
+func checkInstalledVersions(gowrapHome string) []Finding {
+	corrupted, err := versions.FindCorrupted(gowrapHome)
+	if err != nil {
+		return []Finding{{
+			Problem: fmt.Sprintf("failed to check installed versions: %v", err),
+			Advice:  fmt.Sprintf("check the permissions of %s", gowrapHome),
+		}}
+	}
+
+	findings := make([]Finding, 0, len(corrupted))
+	for _, c := range corrupted {
+		version := c.Version
+		finding := Finding{Problem: fmt.Sprintf("corrupted version %s: %s", version, c.Reason)}
+
+		switch {
+		case !semver.IsValid(version):
+			finding.Advice = "remove it with 'gowrap doctor --fix'"
+			finding.Fix = func() error { return versions.Uninstall(gowrapHome, version) }
+		case semver.IsDevel(version):
+			finding.Advice = fmt.Sprintf("remove it with 'gowrap uninstall %s' and build it again with 'gowrap install --from-source <repository>'", version)
+		default:
+			finding.Advice = "reinstall it with 'gowrap doctor --fix'"
+			finding.Fix = func() error { return versions.Reinstall(gowrapHome, version) }
+		}
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// checkPath checks that the first wrapped commands found in the given PATH are
+// the wrappers in binariesDir. Missing wrappers are reported by checkWrappers.
+func checkPath(binariesDir, path string) []Finding {
+	var findings []Finding
+	for _, command := range WrappedCommands {
+		if _, err := os.Stat(filepath.Join(binariesDir, executableName(command))); os.IsNotExist(err) {
+			continue
+		}
+
+		found := lookPath(executableName(command), path)
+		switch {
+		case len(found) == 0:
+			findings = append(findings, Finding{
+				Problem: fmt.Sprintf("%s is not in PATH", command),
+				Advice:  fmt.Sprintf("add %s to PATH", binariesDir),
+			})
+		case !isSameDir(filepath.Dir(found), binariesDir):
+			findings = append(findings, Finding{
+				Problem: fmt.Sprintf("%s shadows the gowrap %s wrapper", found, command),
+				Advice:  fmt.Sprintf("move %s before %s in PATH", binariesDir, filepath.Dir(found)),
+			})
+		}
+	}
+
+	return findings
+}
+
+// checkWrappers checks that the wrappers in binariesDir belong to the same
+// gowrap release as the running gowrap.
+func checkWrappers(binariesDir, gowrapVersion string) []Finding {
+	var findings []Finding
+	for _, command := range WrappedCommands {
+		wrapperPath := filepath.Join(binariesDir, executableName(command))
+		if _, err := os.Stat(wrapperPath); os.IsNotExist(err) {
+			findings = append(findings, Finding{
+				Problem: fmt.Sprintf("gowrap %s wrapper is missing from %s", command, binariesDir),
+				Advice:  "reinstall gowrap",
+			})
+			continue
+		}
+
+		if wrapperVersion := readWrapperVersion(wrapperPath); wrapperVersion != gowrapVersion {
+			findings = append(findings, Finding{
+				Problem: fmt.Sprintf("gowrap %s wrapper is from gowrap %s, but gowrap is %s", command, wrapperVersion, gowrapVersion),
+				Advice:  "reinstall gowrap, so all its binaries come from the same release",
+			})
+		}
+	}
+
+	return findings
+}
+
+// readWrapperVersion returns the gowrap version of the given wrapper, or
+// "unknown" if it can't be read (e.g. wrappers from older releases). The
+// version set at build time can only be read by running the wrapper, so it
+// runs with toolchain switching and auto installs disabled, in case it's from
+// an older release running the wrapped command instead.
+func readWrapperVersion(wrapperPath string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), wrapperVersionTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, wrapperPath)
+	cmd.Env = MergeEnviron(os.Environ(), map[string]string{
+		WrapperVersionEnvVar: "1",
+		GoToolchainEnvVar:    GoToolchainLocal,
+		GoVersionEnvVar:      "",
+	})
+	// a non-zero exit status is returned as an error
+	output, err := cmd.Output()
+	version := strings.TrimSpace(string(output))
+	if err != nil || len(version) == 0 || strings.ContainsAny(version, " \n") {
+		return "unknown"
+	}
+
+	return version
+}
+
+// lookPath returns the path of the first executable with the given name in the
+// given PATH, or an empty string if not found.
+func lookPath(name, path string) string {
+	for _, dir := range filepath.SplitList(path) {
+		if len(dir) == 0 {
+			continue
+		}
+
+		candidate := filepath.Join(dir, name)
+		stat, err := os.Stat(candidate)
+		if err == nil && stat.Mode().IsRegular() && (runtime.GOOS == "windows" || stat.Mode()&0111 != 0) {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+func isSameDir(dir1, dir2 string) bool {
+	if resolved, err := filepath.EvalSymlinks(dir1); err == nil {
+		dir1 = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(dir2); err == nil {
+		dir2 = resolved
+	}
+
+	return filepath.Clean(dir1) == filepath.Clean(dir2)
+}
+
+func executableName(command string) string {
+	if runtime.GOOS == "windows" {
+		return command + ".exe"
+	}
+	return command
+}
@@ -0,0 +1,215 @@
+package common
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/config"
+)
+
+func Test_Diagnose(t *testing.T) {
+	t.Setenv("GOWRAP_CACHE_DIR", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	gowrapHome := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(gowrapHome, "config.json"), []byte(`{"autoInstall": "sometimes"}`), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(gowrapHome, "versions", "go"), 0755))
+
+	var configFinding, versionFinding *Finding
+	for _, finding := range Diagnose(gowrapHome, "1.0.0") {
+		finding := finding
+		switch {
+		case strings.HasPrefix(finding.Problem, "invalid configuration"):
+			configFinding = &finding
+		case strings.HasPrefix(finding.Problem, "corrupted version go"):
+			versionFinding = &finding
+		}
+	}
+
+	require.NotNil(t, configFinding)
+	require.NotNil(t, configFinding.Fix)
+	require.NoError(t, configFinding.Fix())
+	c, err := config.Load(gowrapHome)
+	require.NoError(t, err)
+	assert.NoError(t, c.Validate())
+
+	require.NotNil(t, versionFinding)
+	require.NotNil(t, versionFinding.Fix)
+	require.NoError(t, versionFinding.Fix())
+	assert.NoDirExists(t, filepath.Join(gowrapHome, "versions", "go"))
+}
+
+func Test_checkPath(t *testing.T) {
+	testCases := map[string]struct {
+		wrappers         []string
+		otherCommands    []string
+		path             func(binariesDir, otherDir string) []string
+		expectedProblems func(binariesDir, otherDir string) []string
+	}{
+		"WrappersFirstInPath": {
+			wrappers:      WrappedCommands,
+			otherCommands: WrappedCommands,
+			path: func(binariesDir, otherDir string) []string {
+				return []string{binariesDir, otherDir}
+			},
+			expectedProblems: func(binariesDir, otherDir string) []string {
+				return nil
+			},
+		},
+		"WrappersNotInPath": {
+			wrappers: WrappedCommands,
+			path: func(binariesDir, otherDir string) []string {
+				return []string{otherDir}
+			},
+			expectedProblems: func(binariesDir, otherDir string) []string {
+				return []string{"go is not in PATH", "gofmt is not in PATH"}
+			},
+		},
+		"WrapperShadowed": {
+			wrappers:      WrappedCommands,
+			otherCommands: []string{"go"},
+			path: func(binariesDir, otherDir string) []string {
+				return []string{"", otherDir, binariesDir}
+			},
+			expectedProblems: func(binariesDir, otherDir string) []string {
+				return []string{fmt.Sprintf("%s shadows the gowrap go wrapper", filepath.Join(otherDir, executableName("go")))}
+			},
+		},
+		"MissingWrapper": {
+			wrappers: []string{"go"},
+			path: func(binariesDir, otherDir string) []string {
+				return nil
+			},
+			expectedProblems: func(binariesDir, otherDir string) []string {
+				return []string{"go is not in PATH"}
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			binariesDir := t.TempDir()
+			for _, command := range testCase.wrappers {
+				writeExecutable(t, filepath.Join(binariesDir, executableName(command)), "")
+			}
+
+			otherDir := t.TempDir()
+			for _, command := range testCase.otherCommands {
+				writeExecutable(t, filepath.Join(otherDir, executableName(command)), "")
+			}
+
+			path := strings.Join(testCase.path(binariesDir, otherDir), string(filepath.ListSeparator))
+			findings := checkPath(binariesDir, path)
+			assert.Equal(t, testCase.expectedProblems(binariesDir, otherDir), problemsOf(findings))
+		})
+	}
+}
+
+func Test_checkWrappers(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("wrappers are faked with shell scripts")
+	}
+
+	testCases := map[string]struct {
+		wrapperVersions  map[string]string
+		expectedProblems []string
+	}{
+		"SameVersion": {
+			wrapperVersions: map[string]string{"go": "1.0.0", "gofmt": "1.0.0"},
+		},
+		"MissingWrapper": {
+			wrapperVersions:  map[string]string{"go": "1.0.0"},
+			expectedProblems: []string{"gowrap gofmt wrapper is missing from %s"},
+		},
+		"DifferentVersion": {
+			wrapperVersions:  map[string]string{"go": "0.9.0", "gofmt": "1.0.0"},
+			expectedProblems: []string{"gowrap go wrapper is from gowrap 0.9.0, but gowrap is 1.0.0"},
+		},
+		"UnknownVersion": {
+			wrapperVersions:  map[string]string{"go": "1.0.0", "gofmt": ""},
+			expectedProblems: []string{"gowrap gofmt wrapper is from gowrap unknown, but gowrap is 1.0.0"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			binariesDir := t.TempDir()
+			for command, version := range testCase.wrapperVersions {
+				writeExecutable(t, filepath.Join(binariesDir, command), version)
+			}
+
+			var expectedProblems []string
+			for _, problem := range testCase.expectedProblems {
+				if strings.Contains(problem, "%s") {
+					problem = fmt.Sprintf(problem, binariesDir)
+				}
+				expectedProblems = append(expectedProblems, problem)
+			}
+
+			findings := checkWrappers(binariesDir, "1.0.0")
+			assert.Equal(t, expectedProblems, problemsOf(findings))
+		})
+	}
+}
+
+func Test_readWrapperVersion(t *testing.T) {
+	testCases := map[string]struct {
+		script   string
+		expected string
+	}{
+		"Version": {
+			script:   "echo '1.0.0'",
+			expected: "1.0.0",
+		},
+		"NoVersion": {
+			script:   "true",
+			expected: "unknown",
+		},
+		"FailingWrapper": {
+			script:   "echo '1.0.0'; exit 1",
+			expected: "unknown",
+		},
+		"UnexpectedOutput": {
+			script:   "echo 'go version go1.21.5 linux/amd64'",
+			expected: "unknown",
+		},
+		"ToolchainAndAutoInstallsDisabled": {
+			script:   fmt.Sprintf("[ \"$%s\" = '%s' ] && [ -z \"$%s\" ] && echo '1.0.0'", GoToolchainEnvVar, GoToolchainLocal, GoVersionEnvVar),
+			expected: "1.0.0",
+		},
+	}
+
+	t.Setenv(GoToolchainEnvVar, "go1.21.5+auto")
+	t.Setenv(GoVersionEnvVar, "1.21")
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			wrapperPath := filepath.Join(t.TempDir(), "go")
+			require.NoError(t, ioutil.WriteFile(wrapperPath, []byte("#!/bin/sh\n"+testCase.script+"\n"), 0755))
+
+			assert.Equal(t, testCase.expected, readWrapperVersion(wrapperPath))
+		})
+	}
+}
+
+// writeExecutable writes a script printing the given version when asked for
+// the wrapper version, and nothing otherwise.
+func writeExecutable(t *testing.T, path, version string) {
+	script := fmt.Sprintf("#!/bin/sh\nif [ -n \"$%s\" ]; then echo '%s'; fi\n", WrapperVersionEnvVar, version)
+	require.NoError(t, ioutil.WriteFile(path, []byte(script), 0755))
+}
+
+func problemsOf(findings []Finding) []string {
+	var problems []string
+	for _, finding := range findings {
+		problems = append(problems, finding.Problem)
+	}
+	return problems
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	GoRootEnvVar      = "GOROOT"
+	GoToolchainEnvVar = "GOTOOLCHAIN"
+	GoVersionEnvVar   = "GOWRAP_GO_VERSION"
+	PathEnvVar        = "PATH"
+
+	GoToolchainLocal = "local"
+)
+
+// ToolchainEnvVars returns the environment variables required to use the Go
+// toolchain in goroot: GOROOT pointing to it, its bin directory first in the
+// given PATH and toolchain switching disabled.
+func ToolchainEnvVars(goroot, path string) map[string]string {
+	toolchainPath := filepath.Join(goroot, "bin")
+	if len(path) > 0 {
+		toolchainPath += string(os.PathListSeparator) + path
+	}
+
+	return map[string]string{
+		GoRootEnvVar:      goroot,
+		GoToolchainEnvVar: GoToolchainLocal,
+		PathEnvVar:        toolchainPath,
+	}
+}
+
+// MergeEnviron returns the given environment, in "key=value" form, with the
+// provided overrides applied.
+func MergeEnviron(environ []string, overrides map[string]string) []string {
+	result := make([]string, 0, len(environ)+len(overrides))
+	for _, entry := range environ {
+		key := strings.SplitN(entry, "=", 2)[0]
+		if _, overridden := overrides[key]; !overridden {
+			result = append(result, entry)
+		}
+	}
+
+	for key, value := range overrides {
+		result = append(result, key+"="+value)
+	}
+
+	return result
+}
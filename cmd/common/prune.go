@@ -0,0 +1,112 @@
+package common
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xabierlaiseca/gowrap/pkg/config"
+	"github.com/xabierlaiseca/gowrap/pkg/project"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	"github.com/xabierlaiseca/gowrap/pkg/versions"
+)
+
+// PrunePolicyFor returns the prune policy defined by the configuration.
+func PrunePolicyFor(c *config.Configuration) versions.PrunePolicy {
+	return versions.PrunePolicy{
+		KeepPatches:    c.PruneKeepPatches,
+		KeepUsedWithin: time.Duration(c.PruneKeepUsedDays) * 24 * time.Hour,
+	}
+}
+
+// PruneVersions removes the installed versions not kept by the given policy.
+// The versions used by default and by known projects are never removed, nor
+// the ones in use.
+func PruneVersions(gowrapHome string, policy versions.PrunePolicy, dryRun bool, inUse ...string) ([]string, error) {
+	protected, err := protectedVersions(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	return versions.Prune(gowrapHome, policy, append(protected, inUse...), dryRun)
+}
+
+// RemoveUnprotected removes the given installed versions, except the ones used
+// by default and by known projects, which are returned as kept.
+func RemoveUnprotected(gowrapHome string, toRemove []string) ([]string, []string, error) {
+	protected, err := protectedVersions(gowrapHome)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	isProtected := make(map[string]bool, len(protected))
+	for _, version := range protected {
+		isProtected[version] = true
+	}
+
+	var removed, kept []string
+	for _, version := range toRemove {
+		if isProtected[version] {
+			kept = append(kept, version)
+			continue
+		}
+
+		if err := versions.Uninstall(gowrapHome, version); err != nil {
+			return removed, kept, err
+		}
+		removed = append(removed, version)
+	}
+
+	return removed, kept, nil
+}
+
+// protectedVersions returns the installed versions used by default and by
+// known projects.
+func protectedVersions(gowrapHome string) ([]string, error) {
+	c, err := config.Load(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	var protected []string
+	if semver.IsValid(c.DefaultVersion) {
+		if installed, err := versions.FindLatestInstalledForPrefix(gowrapHome, c.DefaultVersion); err == nil {
+			protected = append(protected, installed)
+		} else if !customerrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	projectRoots, err := project.KnownProjects(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, projectRoot := range projectRoots {
+		version, err := project.DetectVersion(gowrapHome, projectRoot)
+		if err != nil && !customerrors.IsNotFound(err) {
+			return nil, err
+		} else if version != nil && version.IsAvailable() {
+			protected = append(protected, version.Installed)
+		}
+	}
+
+	return protected, nil
+}
+
+// autoPruneIfConfigured prunes installed versions if configured to do it after
+// automatic installs.
+func autoPruneIfConfigured(gowrapHome string, c *config.Configuration, installedVersion string) {
+	if c.AutoPrune != config.AutoPruneEnabled {
+		return
+	}
+
+	pruned, err := PruneVersions(gowrapHome, PrunePolicyFor(c), false, installedVersion)
+	if err != nil {
+		logrus.Warningf("failed to prune installed versions: %v", err)
+	}
+
+	for _, version := range pruned {
+		logrus.Infof("pruned version %s", version)
+	}
+}
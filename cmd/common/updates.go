@@ -75,10 +75,15 @@ func trySelfUpgrade(gowrapHome, currentVersion string) error {
 		return nil
 	}
 
-	return upgrade(release)
+	timeout, err := c.GetDownloadTimeout()
+	if err != nil {
+		return err
+	}
+
+	return upgrade(release, file.DownloadOptions{Timeout: timeout, Retries: c.DownloadRetries})
 }
 
-func upgrade(release *github.RepositoryRelease) error {
+func upgrade(release *github.RepositoryRelease, downloadOptions file.DownloadOptions) error {
 	gowrapAsset, checksum, err := findAsset(release)
 	if err != nil {
 		return err
@@ -90,7 +95,7 @@ func upgrade(release *github.RepositoryRelease) error {
 	}
 	defer os.RemoveAll(downloadsDir)
 
-	archiveContentDir, err := unarchiveRemoteFile(downloadsDir, gowrapAsset, checksum)
+	archiveContentDir, err := unarchiveRemoteFile(downloadsDir, gowrapAsset, checksum, downloadOptions)
 	if err != nil {
 		return err
 	}
@@ -125,9 +130,9 @@ func upgrade(release *github.RepositoryRelease) error {
 	return nil
 }
 
-func unarchiveRemoteFile(downloadsDir string, gowrapAsset *github.ReleaseAsset, checksum string) (string, error) {
+func unarchiveRemoteFile(downloadsDir string, gowrapAsset *github.ReleaseAsset, checksum string, downloadOptions file.DownloadOptions) (string, error) {
 	gowrapArchivePath := filepath.Join(downloadsDir, gowrapAsset.GetName())
-	if err := file.DownloadTo("gowrap", gowrapArchivePath, gowrapAsset.GetBrowserDownloadURL(), checksum, "sha256"); err != nil {
+	if err := file.DownloadTo("gowrap", gowrapArchivePath, gowrapAsset.GetBrowserDownloadURL(), checksum, "sha256", downloadOptions); err != nil {
 		return "", err
 	}
 
@@ -1,23 +1,42 @@
 package cli
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 
-	"github.com/xabierlaiseca/gowrap/pkg/config"
+	"github.com/sirupsen/logrus"
+	"github.com/xabierlaiseca/gowrap/cmd/common"
 	"github.com/xabierlaiseca/gowrap/pkg/project"
 	"github.com/xabierlaiseca/gowrap/pkg/semver"
 	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
 	"github.com/xabierlaiseca/gowrap/pkg/versions"
 )
 
+const goVersionEnvVar = common.GoVersionEnvVar
+
 func GenerateSubCommand(gowrapHome, wd, wrappedCmd string, args []string) (*SubCommand, error) {
-	version, err := findVersionToUse(gowrapHome, wd)
+	toolchain, err := parseGoToolchain(os.Getenv(goToolchainEnvVar))
+	if err != nil {
+		return nil, err
+	}
+
+	versionOverride := strings.TrimSpace(os.Getenv(goVersionEnvVar))
+	if len(versionOverride) > 0 && !semver.IsValid(versionOverride) {
+		return nil, customerrors.Errorf("invalid %s value: %s", goVersionEnvVar, versionOverride)
+	}
+
+	version, projectRoot, err := findVersionToUse(gowrapHome, wd, versionOverride, toolchain)
 	if customerrors.IsNotFound(err) {
 		return nil, customerrors.Errorf("No suitable version found")
 	} else if err != nil {
 		return nil, err
 	}
 
+	if err := versions.RecordUsage(gowrapHome, version, projectRoot); err != nil {
+		logrus.Debugf("failed to record usage of go %s: %v", version, err)
+	}
+
 	versionsDir, err := versions.GetVersionsDir(gowrapHome)
 	if err != nil {
 		return nil, err
@@ -26,55 +45,107 @@ func GenerateSubCommand(gowrapHome, wd, wrappedCmd string, args []string) (*SubC
 	binary := filepath.Join(versionsDir, version, "bin", wrappedCmd)
 	scArgs := []string{wrappedCmd}
 	scArgs = append(scArgs, args...)
+
+	var env map[string]string
+	if len(versionOverride) > 0 || !toolchain.isDefault() {
+		// gowrap already honoured the requested version, so the selected
+		// toolchain must not switch to another one by itself.
+		env = map[string]string{common.GoToolchainEnvVar: common.GoToolchainLocal}
+	}
+
 	return &SubCommand{
 		Binary: binary,
 		Args:   scArgs,
+		Env:    env,
 	}, nil
 }
 
 type SubCommand struct {
 	Binary string
 	Args   []string
+	// Env contains the environment variables to override for the sub command.
+	Env map[string]string
+}
+
+// Environ returns the given environment with the overrides of the sub command
+// applied.
+func (sc *SubCommand) Environ(environ []string) []string {
+	return common.MergeEnviron(environ, sc.Env)
 }
 
-func findVersionToUse(gowrapHome, wd string) (string, error) {
-	detectedVersion, err := project.DetectVersion(gowrapHome, wd)
+// findVersionToUse returns the installed version to use and the root of the
+// project or workspace it was detected from. A version requested through
+// GOWRAP_GO_VERSION is installed following the autoinstall configuration,
+// whatever GOTOOLCHAIN allows.
+func findVersionToUse(gowrapHome, wd, versionOverride string, toolchain *goToolchain) (string, string, error) {
+	detectedVersion, err := detectVersion(gowrapHome, wd, versionOverride, toolchain)
 	if err != nil && !customerrors.IsNotFound(err) {
-		return "", err
+		return "", "", err
 	}
 
-	installedVersion, err := autoInstallVersionIfConfigured(gowrapHome, detectedVersion)
-	if err != nil {
-		return "", err
+	var installedVersion string
+	if len(versionOverride) > 0 || toolchain.allowsInstalls() {
+		installedVersion, err = common.AutoInstallVersionIfConfigured(gowrapHome, detectedVersion)
+		if err != nil {
+			return "", "", err
+		}
 	}
 
-	if len(installedVersion) > 0 {
-		return installedVersion, nil
-	} else if detectedVersion.IsAvailable() {
-		return detectedVersion.Installed, nil
+	switch {
+	case len(installedVersion) > 0:
+		return installedVersion, projectRootOf(detectedVersion), nil
+	case detectedVersion.IsAvailable():
+		return detectedVersion.Installed, projectRootOf(detectedVersion), nil
+	case semver.IsDevel(detectedVersion.Defined):
+		return "", "", customerrors.Errorf("go %s is not installed, run 'gowrap install --from-source <repository>' to build it",
+			detectedVersion.Defined)
+	case detectedVersion.IsDefined():
+		return "", "", customerrors.Errorf("no versions available for go %s installed, run 'gowrap install %s' to install it",
+			detectedVersion.Defined, detectedVersion.Defined)
 	}
-	return "", customerrors.Errorf("no versions available for go %s installed", detectedVersion.Defined)
+	return "", "", customerrors.Error("no go versions installed, run 'gowrap install <version>' to install one")
 }
 
-func autoInstallVersionIfConfigured(gowrapHome string, version *project.Version) (string, error) {
-	c, err := config.Load(gowrapHome)
-	if err != nil {
-		return "", err
+// projectRootOf returns the workspace root of the given version if in a
+// workspace, or its project root otherwise.
+func projectRootOf(version *project.Version) string {
+	if version == nil {
+		return ""
+	} else if len(version.WorkspaceRoot) > 0 {
+		return version.WorkspaceRoot
 	}
 
-	if c.AutoInstall == config.AutoInstallDisabled || (c.AutoInstall == config.AutoInstallMissing && version.IsAvailable()) {
-		return "", nil
-	}
+	return version.ProjectRoot
+}
 
-	candidate, err := versions.FindLatestAvailable(version.Defined)
-	if err != nil {
-		return "", err
+// detectVersion detects the version to use. A version requested through
+// GOWRAP_GO_VERSION has preference over the toolchain requested through
+// GOTOOLCHAIN, and a toolchain with switching allowed is only used if the
+// project does not require a newer version.
+func detectVersion(gowrapHome, wd, versionOverride string, toolchain *goToolchain) (*project.Version, error) {
+	if len(versionOverride) > 0 {
+		return findInstalledVersion(gowrapHome, versionOverride, goVersionEnvVar)
+	} else if len(toolchain.version) == 0 {
+		return project.DetectVersion(gowrapHome, wd)
 	}
 
-	if !semver.IsLessThan(version.Installed, candidate) {
-		return "", nil
+	if len(toolchain.switching) > 0 {
+		detectedVersion, err := project.DetectVersion(gowrapHome, wd)
+		if err != nil && !customerrors.IsNotFound(err) {
+			return nil, err
+		} else if detectedVersion != nil && detectedVersion.IsDefined() && semver.IsLessThan(toolchain.version, detectedVersion.Defined) {
+			return detectedVersion, err
+		}
 	}
 
-	_, err = versions.InstallIfNotInstalled(gowrapHome, candidate)
-	return candidate, err
+	return findInstalledVersion(gowrapHome, toolchain.version, goToolchainEnvVar)
+}
+
+func findInstalledVersion(gowrapHome, prefix, source string) (*project.Version, error) {
+	installedVersion, err := versions.FindLatestInstalledForPrefix(gowrapHome, prefix)
+	return &project.Version{
+		Defined:   prefix,
+		Installed: installedVersion,
+		Source:    source,
+	}, err
 }
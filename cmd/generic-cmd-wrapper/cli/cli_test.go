@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/config"
+)
+
+func Test_GenerateSubCommand_VersionOverrides(t *testing.T) {
+	testCases := map[string]struct {
+		env map[string]string
+
+		expectedVersion string
+		expectedEnv     map[string]string
+	}{
+		"NoOverrides": {
+			expectedVersion: "1.21.0",
+		},
+		"GoVersionOverrideWithTip": {
+			env:             map[string]string{goVersionEnvVar: "tip"},
+			expectedVersion: "tip",
+			expectedEnv:     map[string]string{goToolchainEnvVar: goToolchainLocal},
+		},
+		"GoVersionOverride": {
+			env:             map[string]string{goVersionEnvVar: "1.20"},
+			expectedVersion: "1.20.3",
+			expectedEnv:     map[string]string{goToolchainEnvVar: goToolchainLocal},
+		},
+		"GoToolchain": {
+			env:             map[string]string{goToolchainEnvVar: "go1.20.1"},
+			expectedVersion: "1.20.1",
+			expectedEnv:     map[string]string{goToolchainEnvVar: goToolchainLocal},
+		},
+		"GoVersionOverrideHasPreferenceOverGoToolchain": {
+			env:             map[string]string{goVersionEnvVar: "1.20.3", goToolchainEnvVar: "go1.20.1"},
+			expectedVersion: "1.20.3",
+			expectedEnv:     map[string]string{goToolchainEnvVar: goToolchainLocal},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			t.Setenv(goVersionEnvVar, "")
+			t.Setenv(goToolchainEnvVar, "")
+			for key, value := range testCase.env {
+				t.Setenv(key, value)
+			}
+
+			gowrapHome := createGowrapHome(t, "1.20.1", "1.20.3", "1.21.0", "tip")
+			wd, err := ioutil.TempDir(os.TempDir(), "test-wd-")
+			require.NoError(t, err)
+			defer os.RemoveAll(wd)
+
+			actual, err := GenerateSubCommand(gowrapHome, wd, "go", []string{"version"})
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(gowrapHome, "versions", testCase.expectedVersion, "bin", "go"), actual.Binary)
+			assert.Equal(t, []string{"go", "version"}, actual.Args)
+			assert.Equal(t, testCase.expectedEnv, actual.Env)
+		})
+	}
+}
+
+func Test_GenerateSubCommand_InvalidVersionOverride(t *testing.T) {
+	t.Setenv(goVersionEnvVar, "1.20.a")
+	t.Setenv(goToolchainEnvVar, "")
+
+	_, err := GenerateSubCommand(createGowrapHome(t), os.TempDir(), "go", nil)
+	assert.EqualError(t, err, "invalid GOWRAP_GO_VERSION value: 1.20.a")
+}
+
+// createGowrapHome creates a gowrap home with the given versions installed and
+// auto installs disabled.
+func createGowrapHome(t *testing.T, installedVersions ...string) string {
+	gowrapHome, err := ioutil.TempDir(os.TempDir(), "test-gowrap-home-")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(gowrapHome) })
+
+	for _, version := range installedVersions {
+		binDir := filepath.Join(gowrapHome, "versions", version, "bin")
+		require.NoError(t, os.MkdirAll(binDir, 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(binDir, "go"), []byte{}, 0600))
+	}
+
+	c, err := config.Load(gowrapHome)
+	require.NoError(t, err)
+	c.AutoInstall = config.AutoInstallDisabled
+	require.NoError(t, c.Save())
+
+	return gowrapHome
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/xabierlaiseca/gowrap/cmd/common"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+const (
+	goToolchainEnvVar = common.GoToolchainEnvVar
+
+	goToolchainLocal = common.GoToolchainLocal
+	goToolchainAuto  = "auto"
+	goToolchainPath  = "path"
+)
+
+// goToolchain contains the toolchain selection requested through GOTOOLCHAIN.
+// See https://go.dev/doc/toolchain for the supported forms.
+type goToolchain struct {
+	// version is the requested Go version, empty when the local toolchain is
+	// requested (i.e. the one detected by gowrap).
+	version string
+	// switching is either goToolchainAuto or goToolchainPath if newer
+	// versions required by the project can be used, empty otherwise.
+	switching string
+}
+
+func parseGoToolchain(value string) (*goToolchain, error) {
+	name, switching := value, ""
+	if i := strings.Index(value, "+"); i >= 0 {
+		name, switching = value[:i], value[i+1:]
+		if switching != goToolchainAuto && switching != goToolchainPath {
+			return nil, customerrors.Errorf("invalid %s value: %s", goToolchainEnvVar, value)
+		}
+	}
+
+	switch name {
+	case "", goToolchainAuto:
+		if len(switching) > 0 {
+			return nil, customerrors.Errorf("invalid %s value: %s", goToolchainEnvVar, value)
+		}
+		return &goToolchain{switching: goToolchainAuto}, nil
+	case goToolchainPath:
+		if len(switching) > 0 {
+			return nil, customerrors.Errorf("invalid %s value: %s", goToolchainEnvVar, value)
+		}
+		return &goToolchain{switching: goToolchainPath}, nil
+	case goToolchainLocal:
+		return &goToolchain{switching: switching}, nil
+	}
+
+	version := strings.TrimPrefix(name, "go")
+	if version == name || !semver.IsValid(version) {
+		return nil, customerrors.Errorf("invalid %s value: %s", goToolchainEnvVar, value)
+	}
+
+	return &goToolchain{version: version, switching: switching}, nil
+}
+
+// isDefault returns true if no specific selection was requested, so gowrap
+// can behave as usual.
+func (gt *goToolchain) isDefault() bool {
+	return len(gt.version) == 0 && gt.switching == goToolchainAuto
+}
+
+// allowsInstalls returns true if missing versions can be installed.
+func (gt *goToolchain) allowsInstalls() bool {
+	return gt.switching == goToolchainAuto || (len(gt.version) > 0 && len(gt.switching) == 0)
+}
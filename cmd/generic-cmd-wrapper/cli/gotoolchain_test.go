@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseGoToolchain(t *testing.T) {
+	testCases := map[string]struct {
+		value string
+
+		expected               *goToolchain
+		expectedDefault        bool
+		expectedAllowsInstalls bool
+	}{
+		"NotSet": {
+			value:                  "",
+			expected:               &goToolchain{switching: goToolchainAuto},
+			expectedDefault:        true,
+			expectedAllowsInstalls: true,
+		},
+		"Auto": {
+			value:                  "auto",
+			expected:               &goToolchain{switching: goToolchainAuto},
+			expectedDefault:        true,
+			expectedAllowsInstalls: true,
+		},
+		"LocalWithAuto": {
+			value:                  "local+auto",
+			expected:               &goToolchain{switching: goToolchainAuto},
+			expectedDefault:        true,
+			expectedAllowsInstalls: true,
+		},
+		"Local": {
+			value:    "local",
+			expected: &goToolchain{},
+		},
+		"Path": {
+			value:    "path",
+			expected: &goToolchain{switching: goToolchainPath},
+		},
+		"LocalWithPath": {
+			value:    "local+path",
+			expected: &goToolchain{switching: goToolchainPath},
+		},
+		"Version": {
+			value:                  "go1.22.0",
+			expected:               &goToolchain{version: "1.22.0"},
+			expectedAllowsInstalls: true,
+		},
+		"VersionWithAuto": {
+			value:                  "go1.22.0+auto",
+			expected:               &goToolchain{version: "1.22.0", switching: goToolchainAuto},
+			expectedAllowsInstalls: true,
+		},
+		"VersionWithPath": {
+			value:    "go1.22.0+path",
+			expected: &goToolchain{version: "1.22.0", switching: goToolchainPath},
+		},
+		"PreReleaseVersion": {
+			value:                  "go1.22rc1",
+			expected:               &goToolchain{version: "1.22rc1"},
+			expectedAllowsInstalls: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			actual, err := parseGoToolchain(testCase.value)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, actual)
+			assert.Equal(t, testCase.expectedDefault, actual.isDefault())
+			assert.Equal(t, testCase.expectedAllowsInstalls, actual.allowsInstalls())
+		})
+	}
+}
+
+func Test_parseGoToolchain_Invalid(t *testing.T) {
+	for _, value := range []string{"1.22.0", "go1.22.a", "go1.22.0+local", "auto+auto", "path+auto", "local+other"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := parseGoToolchain(value)
+			assert.EqualError(t, err, "invalid GOTOOLCHAIN value: "+value)
+		})
+	}
+}
+
+func Test_SubCommand_Environ(t *testing.T) {
+	subCommand := &SubCommand{Env: map[string]string{"GOTOOLCHAIN": "local"}}
+
+	actual := subCommand.Environ([]string{"HOME=/home/user", "GOTOOLCHAIN=go1.22.0"})
+	assert.Equal(t, []string{"HOME=/home/user", "GOTOOLCHAIN=local"}, actual)
+}
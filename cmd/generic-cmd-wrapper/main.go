@@ -17,6 +17,11 @@ var (
 )
 
 func main() {
+	if len(os.Getenv(common.WrapperVersionEnvVar)) > 0 {
+		fmt.Println(version)
+		return
+	}
+
 	gowrapHome, err := common.GetGowrapHome()
 	exitOnError(err)
 
@@ -29,7 +34,7 @@ func main() {
 	exitOnError(err)
 
 	binary := subCommand.Binary
-	err = syscall.Exec(binary, subCommand.Args, os.Environ())
+	err = syscall.Exec(binary, subCommand.Args, subCommand.Environ(os.Environ()))
 	exitOnError(err)
 }
 
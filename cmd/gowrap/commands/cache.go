@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/xabierlaiseca/gowrap/pkg/cache"
+	"github.com/xabierlaiseca/gowrap/pkg/config"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+const lastUsedFormat = "2006-01-02 15:04"
+
+func newCacheCommand(app *kingpin.Application, gowrapHome string) {
+	cmd := app.Command("cache", "Cached go archives operations")
+	newCacheListCommand(cmd)
+	newCacheVerifyCommand(cmd)
+	newCachePruneCommand(cmd, gowrapHome)
+}
+
+func newCacheListCommand(parent *kingpin.CmdClause) {
+	parent.Command("list", "Lists cached go archives, most recently used first").
+		Action(func(*kingpin.ParseContext) error {
+			archives, err := cache.ListArchives()
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ARCHIVE\tSIZE\tLAST USED")
+			var total int64
+			for _, archive := range archives {
+				total += archive.Size
+				fmt.Fprintf(w, "%s\t%s\t%s\n", archive.Name, formatSize(archive.Size), archive.LastUsed.Format(lastUsedFormat))
+			}
+			fmt.Fprintf(w, "TOTAL\t%s\t\n", formatSize(total))
+			return w.Flush()
+		})
+}
+
+func newCacheVerifyCommand(parent *kingpin.CmdClause) {
+	parent.Command("verify", "Verifies the checksums of cached go archives, removing corrupted ones").
+		Action(func(*kingpin.ParseContext) error {
+			corrupted, err := cache.VerifyArchives()
+			for _, archive := range corrupted {
+				fmt.Printf("Removed corrupted archive %s\n", archive.Name)
+			}
+
+			if err != nil {
+				return err
+			} else if len(corrupted) > 0 {
+				return customerrors.Errorf("%d corrupted archives found", len(corrupted))
+			}
+			return nil
+		})
+}
+
+func newCachePruneCommand(parent *kingpin.CmdClause, gowrapHome string) {
+	cmd := parent.Command("prune", "Removes cached go archives exceeding the configured limits")
+	all := cmd.Flag("all", "remove all cached go archives").Bool()
+
+	cmd.Action(func(*kingpin.ParseContext) error {
+		c, err := config.Load(gowrapHome)
+		if err != nil {
+			return err
+		}
+
+		var pruned []cache.Archive
+		if *all {
+			pruned, err = cache.ClearArchives()
+		} else {
+			pruned, err = cache.PruneArchives(cache.ArchiveLimits{MaxSize: c.GetArchiveCacheMaxSize(), MaxAge: c.GetArchiveCacheMaxAge()})
+		}
+
+		for _, archive := range pruned {
+			fmt.Printf("Removed archive %s\n", archive.Name)
+		}
+		return err
+	})
+}
+
+func formatSize(size int64) string {
+	return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+}
@@ -2,16 +2,48 @@ package commands
 
 import (
 	"github.com/xabierlaiseca/gowrap/cmd/common"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
 	"github.com/xabierlaiseca/gowrap/pkg/versions"
-	"github.com/xabierlaiseca/gowrap/pkg/versionsfile"
 )
 
 func availableVersionCompletion() []string {
-	return versionCompletionHelper(func(string) bool {
+	return versionCompletionHelper(versions.AvailableFilter{}, func(versions.AvailableVersion) bool {
 		return true
 	})
 }
 
+// availableMinorCompletion completes the minor versions available to install
+// selected by the flags already parsed into the filter.
+func availableMinorCompletion(filter *versions.AvailableFilter) func() []string {
+	return func() []string {
+		minorFilter := *filter
+		minorFilter.Prefix = ""
+		minorFilter.LatestPerMinor = true
+		latestPerMinor := versionCompletionHelper(minorFilter, func(versions.AvailableVersion) bool {
+			return true
+		})
+
+		minors := make([]string, 0, len(latestPerMinor))
+		for _, version := range latestPerMinor {
+			minors = append(minors, semver.Minor(version))
+		}
+		return minors
+	}
+}
+
+// availableSinceCompletion completes the available versions selected by the
+// prefix and flags already parsed into the filter, ignoring the version being
+// completed.
+func availableSinceCompletion(filter *versions.AvailableFilter) func() []string {
+	return func() []string {
+		sinceFilter := *filter
+		sinceFilter.Since = ""
+		return versionCompletionHelper(sinceFilter, func(versions.AvailableVersion) bool {
+			return true
+		})
+	}
+}
+
 func installedVersionCompletion() []string {
 	gowrapHome, err := common.GetGowrapHome()
 	if err != nil {
@@ -27,37 +59,28 @@ func installedVersionCompletion() []string {
 }
 
 func notInstalledVersionCompletion() []string {
-	gowrapHome, err := common.GetGowrapHome()
-	if err != nil {
-		return []string{}
-	}
+	return versionCompletionHelper(versions.AvailableFilter{}, func(version versions.AvailableVersion) bool {
+		return !version.Installed
+	})
+}
 
-	installed, err := versions.ListInstalled(gowrapHome)
+// versionCompletionHelper completes the available versions selected by the
+// given filter, like `gowrap list available` does, and accepted by include.
+func versionCompletionHelper(filter versions.AvailableFilter, include func(versions.AvailableVersion) bool) []string {
+	gowrapHome, err := common.GetGowrapHome()
 	if err != nil {
 		return []string{}
 	}
 
-	alreadyInstalled := make(map[string]bool)
-	for _, v := range installed {
-		alreadyInstalled[v] = true
-	}
-
-	return versionCompletionHelper(func(curr string) bool {
-		_, found := alreadyInstalled[curr]
-		return !found
-	})
-}
-
-func versionCompletionHelper(filter func(string) bool) []string {
-	versionGoArchives, err := versionsfile.Load()
+	available, err := versions.DescribeAvailable(gowrapHome, filter)
 	if err != nil {
 		return []string{}
 	}
 
-	options := make([]string, 0, len(versionGoArchives))
-	for version := range versionGoArchives {
-		if filter(version) {
-			options = append(options, version)
+	options := make([]string, 0, len(available))
+	for _, version := range available {
+		if include(version) {
+			options = append(options, version.Version)
 		}
 	}
 	return options
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/cache"
+	"github.com/xabierlaiseca/gowrap/pkg/versionsfile"
+)
+
+func Test_newListAvailableCommand_Completions(t *testing.T) {
+	testCases := map[string]struct {
+		args     []string
+		expected []string
+	}{
+		"Prefix": {
+			args:     []string{""},
+			expected: []string{"1.20", "1.21", "1.22"},
+		},
+		"PrefixWithSince": {
+			args:     []string{"--since", "1.21.8", ""},
+			expected: []string{"1.21", "1.22"},
+		},
+		"PrefixWithSupported": {
+			args:     []string{"--supported", ""},
+			expected: []string{"1.21", "1.22"},
+		},
+		"Since": {
+			args:     []string{"--since", ""},
+			expected: []string{"1.20.14", "1.21.7", "1.21.8", "1.22.0", "1.22.1"},
+		},
+		"SinceWithPrefix": {
+			args:     []string{"1.21", "--since", ""},
+			expected: []string{"1.21.7", "1.21.8"},
+		},
+		"SinceWithSupportedAndLatestPerMinor": {
+			args:     []string{"--supported", "--latest-per-minor", "--since", ""},
+			expected: []string{"1.21.8", "1.22.1"},
+		},
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GOWRAP_CACHE_DIR", t.TempDir())
+	t.Setenv("GOWRAP_MIRROR", "")
+
+	archives := map[string]versionsfile.GoArchive{}
+	for _, version := range []string{"1.20.14", "1.21.7", "1.21.8", "1.22.0", "1.22.1"} {
+		archives[version] = versionsfile.GoArchive{URL: "https://go.dev/dl/go" + version + ".linux-amd64.tar.gz"}
+	}
+	content, err := json.Marshal(archives)
+	require.NoError(t, err)
+	require.NoError(t, cache.Set("goversions.json", content, time.Hour))
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			app := kingpin.New("gowrap", "")
+			app.Terminate(func(int) {})
+			newListCommand(app, t.TempDir())
+
+			output := captureStdout(t, func() {
+				_, err := app.Parse(append([]string{"--completion-bash", "list", "available"}, testCase.args...))
+				require.NoError(t, err)
+			})
+
+			assert.Equal(t, testCase.expected, strings.Split(string(output), "\n"))
+		})
+	}
+}
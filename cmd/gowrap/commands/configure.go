@@ -2,18 +2,33 @@ package commands
 
 import (
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/alecthomas/kingpin"
 	"github.com/xabierlaiseca/gowrap/pkg/config"
+	"github.com/xabierlaiseca/gowrap/pkg/project"
 	"github.com/xabierlaiseca/gowrap/pkg/semver"
 	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	"github.com/xabierlaiseca/gowrap/pkg/versions"
 )
 
-func newConfigureCommand(app *kingpin.Application, gowrapHome string) {
+func newConfigureCommand(app *kingpin.Application, gowrapHome, wd string) {
 	cmd := app.Command("configure", "configuration related operations")
 	newConfigureDefaultCommand(cmd, gowrapHome)
 	newConfigurationAutoInstallCommand(cmd, gowrapHome)
 	newConfigurationSelfUpgradesCommand(cmd, gowrapHome)
+	newConfigurationVersionFilesCommand(cmd, gowrapHome)
+	newConfigurationMirrorCommand(cmd, gowrapHome)
+	newConfigurationSignatureVerificationCommand(cmd, gowrapHome)
+	newConfigurationDownloadsCommand(cmd, gowrapHome)
+	newConfigurationCacheCommand(cmd, gowrapHome)
+	newConfigurationPruneCommand(cmd, gowrapHome)
+	newConfigurationDirectoryCommand(cmd, gowrapHome, wd)
 }
 
 func newConfigureDefaultCommand(parent *kingpin.CmdClause, gowrapHome string) {
@@ -32,7 +47,7 @@ func newConfigureDefaultCommand(parent *kingpin.CmdClause, gowrapHome string) {
 		})
 
 	cmd.Action(func(*kingpin.ParseContext) error {
-		return config.SetDefaultVersion(gowrapHome, *version)
+		return versions.SetDefaultVersion(gowrapHome, *version)
 	})
 }
 
@@ -73,3 +88,284 @@ func newConfigurationSelfUpgradesCommand(parent *kingpin.CmdClause, gowrapHome s
 		return c.Save()
 	})
 }
+
+func newConfigurationVersionFilesCommand(parent *kingpin.CmdClause, gowrapHome string) {
+	defaultVersionFiles := project.DefaultVersionFiles()
+	cmd := parent.Command("versionfiles", "Configure the version files to read versions from and their precedence").
+		HelpLong(fmt.Sprintf("Version files are read in the given order before go.mod, default order is: %s", strings.Join(defaultVersionFiles, " ")))
+
+	versionFiles := cmd.Arg("files", "version files sorted by precedence").
+		Required().
+		HintOptions(defaultVersionFiles...).
+		Strings()
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			for _, versionFile := range *versionFiles {
+				if !project.IsSupportedVersionFile(versionFile) {
+					return customerrors.Errorf("unsupported version file provided: %s", versionFile)
+				}
+			}
+			return nil
+		}).
+		Action(func(*kingpin.ParseContext) error {
+			c, err := config.Load(gowrapHome)
+			if err != nil {
+				return err
+			}
+
+			c.VersionFiles = *versionFiles
+			return c.Save()
+		})
+}
+
+func newConfigurationMirrorCommand(parent *kingpin.CmdClause, gowrapHome string) {
+	cmd := parent.Command("mirror", "Configure the mirror to download the versions file and go archives from").
+		HelpLong("The mirror must serve versions.json and the go archives under the given base URL, an empty URL removes the configured mirror")
+
+	mirror := cmd.Arg("url", "base URL of the mirror").
+		Required().
+		String()
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			if len(*mirror) == 0 {
+				return nil
+			}
+
+			if u, err := url.Parse(*mirror); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+				return customerrors.Errorf("invalid mirror URL provided: %s", *mirror)
+			}
+			return nil
+		}).
+		Action(func(*kingpin.ParseContext) error {
+			c, err := config.Load(gowrapHome)
+			if err != nil {
+				return err
+			}
+
+			c.Mirror = *mirror
+			return c.Save()
+		})
+}
+
+func newConfigurationSignatureVerificationCommand(parent *kingpin.CmdClause, gowrapHome string) {
+	cmd := parent.Command("signatureverification", "Configure whether the signature of downloaded versions files is verified").
+		HelpLong("Disabling signature verification allows using versions files that are not signed, such as the ones served by some mirrors, " +
+			"but checksums of go archives can no longer be trusted")
+
+	typeArg := cmd.Arg("type", "whether to verify versions file signatures").Required()
+	signatureVerificationType := asEnum(typeArg, config.SignatureVerificationEnabled, config.SignatureVerificationDisabled)
+
+	cmd.Action(func(*kingpin.ParseContext) error {
+		c, err := config.Load(gowrapHome)
+		if err != nil {
+			return err
+		}
+
+		c.SignatureVerification = *signatureVerificationType
+		return c.Save()
+	})
+}
+
+func newConfigurationDownloadsCommand(parent *kingpin.CmdClause, gowrapHome string) {
+	cmd := parent.Command("downloads", "Configure how go archives are downloaded")
+	var timeoutSet, retriesSet bool
+	timeout := cmd.Flag("timeout", fmt.Sprintf("maximum duration of each download attempt (default %s)", config.DefaultDownloadTimeout)).
+		IsSetByUser(&timeoutSet).
+		Duration()
+	retries := cmd.Flag("retries", fmt.Sprintf("times to retry a download after transient errors (default %d)", config.DefaultDownloadRetries)).
+		IsSetByUser(&retriesSet).
+		Int()
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			if *timeout <= 0 && timeoutSet {
+				return customerrors.Errorf("invalid timeout provided: %s", *timeout)
+			} else if *retries < 0 {
+				return customerrors.Errorf("invalid retries provided: %d", *retries)
+			}
+			return nil
+		}).
+		Action(func(*kingpin.ParseContext) error {
+			c, err := config.Load(gowrapHome)
+			if err != nil {
+				return err
+			}
+
+			if timeoutSet {
+				c.DownloadTimeout = timeout.String()
+			}
+			if retriesSet {
+				c.DownloadRetries = *retries
+			}
+			return c.Save()
+		})
+}
+
+func newConfigurationCacheCommand(parent *kingpin.CmdClause, gowrapHome string) {
+	cmd := parent.Command("cache", "Configure the limits of cached go archives, 0 means no limit")
+	var maxSizeSet, maxAgeSet bool
+	maxSize := cmd.Flag("max-size-mb", fmt.Sprintf("maximum size in megabytes of cached go archives (default %d)", config.DefaultArchiveCacheMaxSizeMB)).
+		IsSetByUser(&maxSizeSet).
+		Int64()
+	maxAge := cmd.Flag("max-age-days", fmt.Sprintf("days to keep cached go archives since last used (default %d)", config.DefaultArchiveCacheMaxAgeDays)).
+		IsSetByUser(&maxAgeSet).
+		Int()
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			if *maxSize < 0 {
+				return customerrors.Errorf("invalid max size provided: %d", *maxSize)
+			} else if *maxAge < 0 {
+				return customerrors.Errorf("invalid max age provided: %d", *maxAge)
+			}
+			return nil
+		}).
+		Action(func(*kingpin.ParseContext) error {
+			c, err := config.Load(gowrapHome)
+			if err != nil {
+				return err
+			}
+
+			if maxSizeSet {
+				c.ArchiveCacheMaxSizeMB = *maxSize
+			}
+			if maxAgeSet {
+				c.ArchiveCacheMaxAgeDays = *maxAge
+			}
+			return c.Save()
+		})
+}
+
+func newConfigurationPruneCommand(parent *kingpin.CmdClause, gowrapHome string) {
+	cmd := parent.Command("prune", "Configure the retention policy of installed go versions").
+		HelpLong(fmt.Sprintf("If auto is set to '%s', installed versions are pruned after automatic installs", config.AutoPruneEnabled))
+
+	var keepPatchesSet, keepUsedDaysSet bool
+	auto := cmd.Flag("auto", "whether to prune after automatic installs").
+		PlaceHolder(config.AutoPruneEnabled+"|"+config.AutoPruneDisabled).
+		Enum(config.AutoPruneEnabled, config.AutoPruneDisabled)
+	keepPatches := cmd.Flag("keep-patches", fmt.Sprintf("number of latest versions to keep per minor version (default %d)", config.DefaultPruneKeepPatches)).
+		IsSetByUser(&keepPatchesSet).
+		Int()
+	keepUsedDays := cmd.Flag("keep-used-days", fmt.Sprintf("keep versions used within this number of days (default %d)", config.DefaultPruneKeepUsedDays)).
+		IsSetByUser(&keepUsedDaysSet).
+		Int()
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			return validatePrunePolicy(*keepPatches, *keepUsedDays)
+		}).
+		Action(func(*kingpin.ParseContext) error {
+			c, err := config.Load(gowrapHome)
+			if err != nil {
+				return err
+			}
+
+			if len(*auto) > 0 {
+				c.AutoPrune = *auto
+			}
+			if keepPatchesSet {
+				c.PruneKeepPatches = *keepPatches
+			}
+			if keepUsedDaysSet {
+				c.PruneKeepUsedDays = *keepUsedDays
+			}
+			return c.Save()
+		})
+}
+
+func newConfigurationDirectoryCommand(parent *kingpin.CmdClause, gowrapHome, wd string) {
+	cmd := parent.Command("directory", "Configure the go versions to use in directories without a project version").
+		HelpLong("Versions configured for a directory apply to its subdirectories too, unless they belong to a project defining " +
+			"a version. They have preference over the default version.")
+	newConfigurationDirectorySetCommand(cmd, gowrapHome, wd)
+	newConfigurationDirectoryUnsetCommand(cmd, gowrapHome, wd)
+	newConfigurationDirectoryListCommand(cmd, gowrapHome)
+}
+
+func newConfigurationDirectorySetCommand(parent *kingpin.CmdClause, gowrapHome, wd string) {
+	cmd := parent.Command("set", "Set the go version to use in a directory")
+	directory := cmd.Arg("directory", "directory to use the version in").
+		Required().
+		ExistingDir()
+	version := cmd.Arg("version", "version to use").
+		Required().
+		HintAction(availableVersionCompletion).
+		String()
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			if semver.IsValid(*version) {
+				return nil
+			}
+			return customerrors.Errorf("invalid version provided: %s", *version)
+		}).
+		Action(func(*kingpin.ParseContext) error {
+			c, err := config.Load(gowrapHome)
+			if err != nil {
+				return err
+			}
+
+			c.SetDirectoryVersion(absPath(wd, *directory), *version)
+			return c.Save()
+		})
+}
+
+func newConfigurationDirectoryUnsetCommand(parent *kingpin.CmdClause, gowrapHome, wd string) {
+	cmd := parent.Command("unset", "Unset the go version to use in a directory")
+	directory := cmd.Arg("directory", "directory to unset the version of").
+		Required().
+		String()
+
+	cmd.Action(func(*kingpin.ParseContext) error {
+		c, err := config.Load(gowrapHome)
+		if err != nil {
+			return err
+		}
+
+		path := absPath(wd, *directory)
+		if !c.UnsetDirectoryVersion(path) {
+			return customerrors.Errorf("no version configured for directory %s", path)
+		}
+		return c.Save()
+	})
+}
+
+func newConfigurationDirectoryListCommand(parent *kingpin.CmdClause, gowrapHome string) {
+	parent.Command("list", "List the go versions configured for directories").
+		Action(func(*kingpin.ParseContext) error {
+			c, err := config.Load(gowrapHome)
+			if err != nil {
+				return err
+			}
+
+			directories := make([]string, 0, len(c.DirectoryVersions))
+			for directory := range c.DirectoryVersions {
+				directories = append(directories, directory)
+			}
+			sort.Strings(directories)
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "DIRECTORY\tVERSION")
+			for _, directory := range directories {
+				fmt.Fprintf(w, "%s\t%s\n", directory, c.DirectoryVersions[directory])
+			}
+			return w.Flush()
+		})
+}
+
+// absPath returns the given path as an absolute path, resolving relative paths
+// from wd and symlinks, so it matches the directories versions are detected in.
+// Symlinks are kept if they can't be resolved, e.g. for removed directories.
+func absPath(wd, path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(wd, path)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
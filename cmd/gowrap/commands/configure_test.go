@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_absPath(t *testing.T) {
+	wd, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(wd, "projects", "legacy"), 0755))
+	require.NoError(t, os.Symlink(filepath.Join(wd, "projects"), filepath.Join(wd, "linked")))
+
+	testCases := map[string]struct {
+		path     string
+		expected string
+	}{
+		"RelativePath": {
+			path:     "projects/legacy",
+			expected: filepath.Join(wd, "projects", "legacy"),
+		},
+		"AbsolutePath": {
+			path:     filepath.Join(wd, "projects", "..", "projects", "legacy"),
+			expected: filepath.Join(wd, "projects", "legacy"),
+		},
+		"Symlink": {
+			path:     "linked/legacy",
+			expected: filepath.Join(wd, "projects", "legacy"),
+		},
+		"RemovedDirectory": {
+			path:     "linked/removed/",
+			expected: filepath.Join(wd, "linked", "removed"),
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, absPath(wd, testCase.path))
+		})
+	}
+}
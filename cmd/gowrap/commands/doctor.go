@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/xabierlaiseca/gowrap/cmd/common"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+func newDoctorCommand(app *kingpin.Application, gowrapVersion, gowrapHome string) {
+	cmd := app.Command("doctor", "Diagnoses problems with the gowrap installation").
+		HelpLong("Checks for go commands shadowing the wrappers in PATH, wrappers from other gowrap releases, " +
+			"corrupted installed versions, an invalid configuration and broken cache metadata.")
+	fix := cmd.Flag("fix", "reinstall corrupted versions, rebuild cache metadata and reset an invalid configuration").
+		Bool()
+
+	cmd.Action(func(*kingpin.ParseContext) error {
+		findings := common.Diagnose(gowrapHome, gowrapVersion)
+		if len(findings) == 0 {
+			fmt.Println("No problems found")
+			return nil
+		}
+
+		unresolved := 0
+		for _, finding := range findings {
+			fmt.Printf("- %s\n", finding.Problem)
+			if !*fix || finding.Fix == nil {
+				fmt.Printf("  %s\n", finding.Advice)
+				unresolved++
+			} else if err := finding.Fix(); err != nil {
+				fmt.Printf("  failed to fix it: %v\n", err)
+				unresolved++
+			} else {
+				fmt.Println("  fixed")
+			}
+		}
+
+		if unresolved > 0 {
+			return customerrors.Errorf("%d problem(s) found", unresolved)
+		}
+		return nil
+	})
+}
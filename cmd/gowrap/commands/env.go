@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/xabierlaiseca/gowrap/cmd/common"
+	"github.com/xabierlaiseca/gowrap/pkg/project"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	"github.com/xabierlaiseca/gowrap/pkg/versions"
+)
+
+const (
+	shellBash = "bash"
+	shellZsh  = "zsh"
+	shellFish = "fish"
+	shellJSON = "json"
+
+	versionFlagSource = "--version"
+)
+
+// toolchainEnv contains the details of the toolchain to use and the
+// environment variables required to use it.
+type toolchainEnv struct {
+	Version       string            `json:"version"`
+	Defined       string            `json:"defined,omitempty"`
+	Source        string            `json:"source,omitempty"`
+	ProjectRoot   string            `json:"projectRoot,omitempty"`
+	WorkspaceRoot string            `json:"workspaceRoot,omitempty"`
+	GoRoot        string            `json:"goroot"`
+	Env           map[string]string `json:"env"`
+}
+
+func newEnvCommand(app *kingpin.Application, gowrapHome, wd string) {
+	cmd := app.Command("env", "Prints the environment variables to use the go version of the current directory")
+	shell := cmd.Flag("shell", "shell to print the environment variables for").
+		Default(shellBash).
+		Enum(shellBash, shellZsh, shellFish, shellJSON)
+	version := cmd.Flag("version", "version to use instead of the one detected for the current directory").
+		HintAction(installedVersionCompletion).
+		String()
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			if len(*version) == 0 || semver.IsValid(*version) {
+				return nil
+			}
+			return customerrors.Errorf("invalid version provided: %s", *version)
+		}).
+		Action(func(*kingpin.ParseContext) error {
+			env, err := findToolchainEnv(gowrapHome, wd, *version)
+			if err != nil {
+				return err
+			}
+
+			output, err := formatToolchainEnv(*shell, env)
+			if err != nil {
+				return err
+			}
+
+			fmt.Print(output)
+			return nil
+		})
+}
+
+func findToolchainEnv(gowrapHome, wd, prefix string) (*toolchainEnv, error) {
+	var version *project.Version
+	var err error
+	if len(prefix) > 0 {
+		version = &project.Version{Defined: prefix, Source: versionFlagSource}
+		version.Installed, err = versions.FindLatestInstalledForPrefix(gowrapHome, prefix)
+	} else {
+		version, err = project.DetectVersion(gowrapHome, wd)
+	}
+
+	if err != nil && !customerrors.IsNotFound(err) {
+		return nil, err
+	} else if !version.IsAvailable() && version.IsDefined() {
+		return nil, customerrors.Errorf("no versions available for go %s installed, run 'gowrap install %s' to install it", version.Defined, version.Defined)
+	} else if !version.IsAvailable() {
+		return nil, customerrors.Error("no go versions installed, run 'gowrap install <version>' to install one")
+	}
+
+	versionsDir, err := versions.GetVersionsDir(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	goroot := filepath.Join(versionsDir, version.Installed)
+	return &toolchainEnv{
+		Version:       version.Installed,
+		Defined:       version.Defined,
+		Source:        version.Source,
+		ProjectRoot:   version.ProjectRoot,
+		WorkspaceRoot: version.WorkspaceRoot,
+		GoRoot:        goroot,
+		Env:           common.ToolchainEnvVars(goroot, os.Getenv(common.PathEnvVar)),
+	}, nil
+}
+
+func formatToolchainEnv(shell string, env *toolchainEnv) (string, error) {
+	switch shell {
+	case shellBash, shellZsh:
+		return formatShellEnv(env, "export %s=%s\n", "export %s=%s:\"$%s\"\n", quotePosix), nil
+	case shellFish:
+		return formatShellEnv(env, "set -gx %s %s\n", "set -gx %s %s $%s\n", quoteFish), nil
+	case shellJSON:
+		content, err := json.MarshalIndent(env, "", "  ")
+		return string(content) + "\n", err
+	}
+
+	return "", customerrors.Errorf("unsupported shell: %s", shell)
+}
+
+// formatShellEnv formats the environment variables for a shell, keeping the
+// current PATH as a reference so it can be evaluated at any time.
+func formatShellEnv(env *toolchainEnv, setFormat, prependPathFormat string, quote func(string) string) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("# go %s", env.Version))
+	if len(env.Defined) > 0 {
+		builder.WriteString(fmt.Sprintf(" (defined: %s, source: %s)", env.Defined, env.Source))
+	}
+	builder.WriteString("\n")
+
+	keys := make([]string, 0, len(env.Env))
+	for key := range env.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key == common.PathEnvVar {
+			toolchainBin := filepath.Join(env.GoRoot, "bin")
+			builder.WriteString(fmt.Sprintf(prependPathFormat, key, quote(toolchainBin), key))
+		} else {
+			builder.WriteString(fmt.Sprintf(setFormat, key, quote(env.Env[key])))
+		}
+	}
+
+	return builder.String()
+}
+
+func quotePosix(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func quoteFish(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(value) + "'"
+}
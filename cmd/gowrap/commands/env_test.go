@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_formatToolchainEnv(t *testing.T) {
+	env := &toolchainEnv{
+		Version: "1.21.6",
+		Defined: "1.21",
+		Source:  "go",
+		GoRoot:  "/home/user's/.gowrap/versions/1.21.6",
+		Env: map[string]string{
+			"GOROOT":      "/home/user's/.gowrap/versions/1.21.6",
+			"GOTOOLCHAIN": "local",
+			"PATH":        "/home/user's/.gowrap/versions/1.21.6/bin:/usr/bin",
+		},
+	}
+
+	testCases := map[string]struct {
+		shell    string
+		expected []string
+	}{
+		"Bash": {
+			shell: shellBash,
+			expected: []string{
+				"# go 1.21.6 (defined: 1.21, source: go)",
+				`export GOROOT='/home/user'\''s/.gowrap/versions/1.21.6'`,
+				`export GOTOOLCHAIN='local'`,
+				`export PATH='/home/user'\''s/.gowrap/versions/1.21.6/bin':"$PATH"`,
+			},
+		},
+		"Zsh": {
+			shell: shellZsh,
+			expected: []string{
+				"# go 1.21.6 (defined: 1.21, source: go)",
+				`export GOROOT='/home/user'\''s/.gowrap/versions/1.21.6'`,
+				`export GOTOOLCHAIN='local'`,
+				`export PATH='/home/user'\''s/.gowrap/versions/1.21.6/bin':"$PATH"`,
+			},
+		},
+		"Fish": {
+			shell: shellFish,
+			expected: []string{
+				"# go 1.21.6 (defined: 1.21, source: go)",
+				`set -gx GOROOT '/home/user\'s/.gowrap/versions/1.21.6'`,
+				`set -gx GOTOOLCHAIN 'local'`,
+				`set -gx PATH '/home/user\'s/.gowrap/versions/1.21.6/bin' $PATH`,
+			},
+		},
+		"JSON": {
+			shell: shellJSON,
+			expected: []string{
+				`{`,
+				`  "version": "1.21.6",`,
+				`  "defined": "1.21",`,
+				`  "source": "go",`,
+				`  "goroot": "/home/user's/.gowrap/versions/1.21.6",`,
+				`  "env": {`,
+				`    "GOROOT": "/home/user's/.gowrap/versions/1.21.6",`,
+				`    "GOTOOLCHAIN": "local",`,
+				`    "PATH": "/home/user's/.gowrap/versions/1.21.6/bin:/usr/bin"`,
+				`  }`,
+				`}`,
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			actual, err := formatToolchainEnv(testCase.shell, env)
+			require.NoError(t, err)
+			assert.Equal(t, strings.Join(testCase.expected, "\n")+"\n", actual)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/xabierlaiseca/gowrap/cmd/common"
+	"github.com/xabierlaiseca/gowrap/pkg/project"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	"github.com/xabierlaiseca/gowrap/pkg/versions"
+)
+
+func newExecCommand(app *kingpin.Application, gowrapHome string) {
+	cmd := app.Command("exec", "Executes a command using the given go version").
+		HelpLong("The command is executed with the bin directory of the go version first in PATH and GOROOT pointing to it, " +
+			"use '--' before the command if it has flags, e.g. 'gowrap exec 1.21 -- make -j4'")
+	version := cmd.Arg("version", "version to use").
+		Required().
+		HintAction(installedVersionCompletion).
+		String()
+	command := cmd.Arg("command", "command to execute, preceded by '--' if it has flags").
+		Required().
+		Strings()
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			if len(*version) == 0 || semver.IsValid(*version) {
+				return nil
+			}
+			return customerrors.Errorf("invalid version provided: %s", *version)
+		}).
+		Action(func(*kingpin.ParseContext) error {
+			return execWithVersion(gowrapHome, *version, *command)
+		})
+}
+
+func execWithVersion(gowrapHome, prefix string, command []string) error {
+	goroot, err := findGoRootForPrefix(gowrapHome, prefix)
+	if err != nil {
+		return err
+	}
+
+	envVars := common.ToolchainEnvVars(goroot, os.Getenv(common.PathEnvVar))
+	if err := os.Setenv(common.PathEnvVar, envVars[common.PathEnvVar]); err != nil {
+		return err
+	}
+
+	binary, err := exec.LookPath(command[0])
+	if err != nil {
+		return err
+	}
+
+	return syscall.Exec(binary, command, common.MergeEnviron(os.Environ(), envVars))
+}
+
+// findGoRootForPrefix returns the GOROOT of the latest installed version for
+// the given prefix, installing a newer version if configured to do so.
+func findGoRootForPrefix(gowrapHome, prefix string) (string, error) {
+	installedVersion, err := versions.FindLatestInstalledForPrefix(gowrapHome, prefix)
+	if err != nil && !customerrors.IsNotFound(err) {
+		return "", err
+	}
+
+	version := &project.Version{Defined: prefix, Installed: installedVersion}
+	if autoInstalledVersion, err := common.AutoInstallVersionIfConfigured(gowrapHome, version); err != nil {
+		return "", err
+	} else if len(autoInstalledVersion) > 0 {
+		installedVersion = autoInstalledVersion
+	}
+
+	if len(installedVersion) == 0 {
+		return "", customerrors.Errorf("no versions available for go %s installed, run 'gowrap install %s' to install it", prefix, prefix)
+	}
+
+	versionsDir, err := versions.GetVersionsDir(gowrapHome)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(versionsDir, installedVersion), nil
+}
@@ -1,26 +1,103 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
 	"github.com/alecthomas/kingpin"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
 	"github.com/xabierlaiseca/gowrap/pkg/versions"
 )
 
 func newListCommand(app *kingpin.Application, gowrapHome string) {
 	cmd := app.Command("list", "List operations")
-	newListAvailableCommand(cmd)
+	newListAvailableCommand(cmd, gowrapHome)
 	newListInstalledCommand(cmd, gowrapHome)
 }
 
-func newListAvailableCommand(parent *kingpin.CmdClause) {
-	parent.Command("available", "Lists the available go versions to install").
+func newListAvailableCommand(parent *kingpin.CmdClause, gowrapHome string) {
+	cmd := parent.Command("available", "Lists the available go versions to install")
+	var filter versions.AvailableFilter
+	cmd.Arg("prefix", "only list versions with this prefix").
+		HintAction(availableMinorCompletion(&filter)).
+		StringVar(&filter.Prefix)
+	cmd.Flag("since", "only list versions equal to or newer than this one").
+		HintAction(availableSinceCompletion(&filter)).
+		StringVar(&filter.Since)
+	cmd.Flag("latest-per-minor", "only list the latest version of each minor version").
+		BoolVar(&filter.LatestPerMinor)
+	cmd.Flag("supported", "only list versions of the minor versions still supported by Go").
+		BoolVar(&filter.Supported)
+	output := outputFlag(cmd)
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			for _, version := range []string{filter.Prefix, filter.Since} {
+				if len(version) > 0 && !semver.IsValid(version) {
+					return customerrors.Errorf("invalid version provided: %s", version)
+				}
+			}
+			return nil
+		}).
 		Action(func(*kingpin.ParseContext) error {
-			return versions.PrintAvailable()
+			available, err := versions.DescribeAvailable(gowrapHome, filter)
+			if err != nil {
+				return err
+			} else if *output == outputJSON {
+				return printJSON(available)
+			}
+
+			for _, version := range available {
+				if version.Installed {
+					fmt.Printf("%s (installed)\n", version.Version)
+				} else {
+					fmt.Println(version.Version)
+				}
+			}
+			return nil
 		})
 }
 
 func newListInstalledCommand(parent *kingpin.CmdClause, gowrapHome string) {
-	parent.Command("installed", "Lists installed go versions").
-		Action(func(*kingpin.ParseContext) error {
-			return versions.PrintInstalled(gowrapHome)
-		})
+	cmd := parent.Command("installed", "Lists installed go versions")
+	verbose := cmd.Flag("verbose", "Show when and from which project each version was last used").
+		Short('v').
+		Bool()
+	output := outputFlag(cmd)
+
+	cmd.Action(func(*kingpin.ParseContext) error {
+		installed, err := versions.DescribeInstalled(gowrapHome)
+		switch {
+		case err != nil:
+			return err
+		case *output == outputJSON:
+			return printJSON(installed)
+		case *verbose:
+			return printInstalledVerbose(installed)
+		}
+
+		for _, version := range installed {
+			fmt.Println(version.Version)
+		}
+		return nil
+	})
+}
+
+// printInstalledVerbose prints the installed versions with the last time each
+// version was used and the project it was used from.
+func printInstalledVerbose(installed []versions.InstalledVersion) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tLAST USED\tPROJECT")
+	for _, version := range installed {
+		lastUsed, projectRoot := "-", "-"
+		if version.Usage != nil {
+			lastUsed = version.Usage.LastUsed.Format(lastUsedFormat)
+			projectRoot = orDash(version.Usage.ProjectRoot)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", version.Version, lastUsed, projectRoot)
+	}
+
+	return w.Flush()
 }
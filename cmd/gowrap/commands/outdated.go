@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/xabierlaiseca/gowrap/pkg/config"
+	"github.com/xabierlaiseca/gowrap/pkg/project"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	"github.com/xabierlaiseca/gowrap/pkg/versions"
+)
+
+const (
+	outdatedSourceDefault = "default"
+	outdatedSourceProject = "project"
+)
+
+func newOutdatedCommand(app *kingpin.Application, gowrapHome, wd string) {
+	cmd := app.Command("outdated", "Lists installed, default and project go versions with newer versions available")
+	exitCode := cmd.Flag("exit-code", "exit with a non-zero code if the project go version is behind the latest patch of its minor version").
+		Bool()
+
+	cmd.Action(func(*kingpin.ParseContext) error {
+		outdated, err := versions.CheckOutdatedInstalled(gowrapHome)
+		if err != nil {
+			return err
+		}
+
+		if defaultOutdated, err := checkDefaultOutdated(gowrapHome); err != nil {
+			return err
+		} else if defaultOutdated != nil {
+			outdated = append(outdated, defaultOutdated)
+		}
+
+		projectOutdated, err := checkProjectOutdated(gowrapHome, wd)
+		if err != nil {
+			return err
+		} else if projectOutdated != nil {
+			outdated = append(outdated, projectOutdated)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SOURCE\tCURRENT\tLATEST PATCH\tLATEST MINOR\tSTATUS")
+		for _, o := range outdated {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", o.Source, o.Current, orDash(o.LatestPatch), orDash(o.LatestMinor), outdatedStatus(o))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if *exitCode && projectOutdated != nil && projectOutdated.IsBehindPatch() {
+			return customerrors.Errorf("project go version %s is behind the latest patch %s", projectOutdated.Current, projectOutdated.LatestPatch)
+		}
+		return nil
+	})
+}
+
+// checkDefaultOutdated compares the default version with the latest available
+// ones, or returns nil if no default version is configured. Versions defined
+// as a prefix always use its latest installed version, so they are never
+// behind.
+func checkDefaultOutdated(gowrapHome string) (*versions.Outdated, error) {
+	c, err := config.Load(gowrapHome)
+	if err != nil || !semver.IsValid(c.DefaultVersion) {
+		return nil, err
+	}
+
+	return versions.CheckOutdated(gowrapHome, outdatedSourceDefault, c.DefaultVersion)
+}
+
+// checkProjectOutdated compares the version of the project in the given
+// directory with the latest available ones, or returns nil if not in a project
+// pinning a version, which is compared like the default version.
+func checkProjectOutdated(gowrapHome, wd string) (*versions.Outdated, error) {
+	version, err := project.DetectVersion(gowrapHome, wd)
+	if err != nil && !customerrors.IsNotFound(err) {
+		return nil, err
+	} else if version == nil || !version.IsDefined() || !isProjectPinnedSource(version.Source) {
+		return nil, nil
+	}
+
+	return versions.CheckOutdated(gowrapHome, outdatedSourceProject, version.Defined)
+}
+
+// isProjectPinnedSource returns true if the given source pins the go version of
+// a project, either a version file or the toolchain directive. The go directive
+// only sets the minimum version required, so it doesn't pin any version.
+func isProjectPinnedSource(source string) bool {
+	switch source {
+	case project.SourceGo, project.SourceDirectory, project.SourceDefault:
+		return false
+	}
+	return true
+}
+
+func outdatedStatus(o *versions.Outdated) string {
+	switch {
+	case o.IsBehindPatch():
+		return "patch available"
+	case o.IsBehindMinor():
+		return "minor available"
+	}
+	return "up to date"
+}
+
+func orDash(value string) string {
+	if len(value) == 0 {
+		return "-"
+	}
+	return value
+}
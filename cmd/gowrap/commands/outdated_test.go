@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xabierlaiseca/gowrap/pkg/project"
+)
+
+func Test_isProjectPinnedSource(t *testing.T) {
+	testCases := map[string]struct {
+		source   string
+		expected bool
+	}{
+		"GoVersionFile": {
+			source:   project.SourceGoVersionFile,
+			expected: true,
+		},
+		"ToolVersionsFile": {
+			source:   ".tool-versions",
+			expected: true,
+		},
+		"Toolchain": {
+			source:   project.SourceToolchain,
+			expected: true,
+		},
+		"GoDirective": {
+			source:   project.SourceGo,
+			expected: false,
+		},
+		"Directory": {
+			source:   project.SourceDirectory,
+			expected: false,
+		},
+		"Default": {
+			source:   project.SourceDefault,
+			expected: false,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			actual := isProjectPinnedSource(testCase.source)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/alecthomas/kingpin"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+func outputFlag(cmd *kingpin.CmdClause) *string {
+	return cmd.Flag("output", "output format").
+		Short('o').
+		Default(outputText).
+		PlaceHolder(outputText+"|"+outputJSON).
+		Enum(outputText, outputJSON)
+}
+
+func printJSON(value interface{}) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(content))
+	return nil
+}
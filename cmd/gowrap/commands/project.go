@@ -2,21 +2,23 @@ package commands
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/alecthomas/kingpin"
 	"github.com/xabierlaiseca/gowrap/pkg/project"
 	"github.com/xabierlaiseca/gowrap/pkg/semver"
 	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	"github.com/xabierlaiseca/gowrap/pkg/versions"
 )
 
 func newProjectCommand(app *kingpin.Application, gowrapHome, wd string) {
 	cmd := app.Command("project", "Project operations")
-	newProjectPinCommand(cmd, wd)
-	newProjectUnpinCommand(cmd, wd)
+	newProjectPinCommand(cmd, gowrapHome, wd)
+	newProjectUnpinCommand(cmd, gowrapHome, wd)
 	newProjectVersionCommand(cmd, gowrapHome, wd)
 }
 
-func newProjectPinCommand(parent *kingpin.CmdClause, wd string) {
+func newProjectPinCommand(parent *kingpin.CmdClause, gowrapHome, wd string) {
 	cmd := parent.Command("pin", "Pin specific version for current project")
 	version := cmd.Arg("version", "version to pin").
 		Required().
@@ -28,41 +30,83 @@ func newProjectPinCommand(parent *kingpin.CmdClause, wd string) {
 			if len(*version) == 0 || (semver.IsValid(*version) && semver.IsFullVersion(*version)) {
 				return nil
 			}
-			return customerrors.Errorf("invalid version provided: %s, 'a.b.c' like version required", *version)
+			return customerrors.Errorf("invalid version provided: %s, 'a.b.c' or 'a.brcN' like version required", *version)
 		}).
 		Action(func(*kingpin.ParseContext) error {
-			return project.PinVersion(wd, *version)
+			return project.PinVersion(gowrapHome, wd, *version)
 		})
 }
 
-func newProjectUnpinCommand(parent *kingpin.CmdClause, wd string) {
+func newProjectUnpinCommand(parent *kingpin.CmdClause, gowrapHome, wd string) {
 	parent.Command("unpin", "Unpin specific version for current project").
 		Action(func(*kingpin.ParseContext) error {
-			return project.UnpinVersion(wd)
+			return project.UnpinVersion(gowrapHome, wd)
 		})
 }
 
+// projectVersion contains the details of the go version used by a project.
+type projectVersion struct {
+	Defined       string `json:"defined,omitempty"`
+	Installed     string `json:"installed,omitempty"`
+	InstallPath   string `json:"installPath,omitempty"`
+	Source        string `json:"source,omitempty"`
+	SourcePath    string `json:"sourcePath,omitempty"`
+	ProjectRoot   string `json:"projectRoot,omitempty"`
+	WorkspaceRoot string `json:"workspaceRoot,omitempty"`
+}
+
 func newProjectVersionCommand(parent *kingpin.CmdClause, gowrapHome, wd string) {
-	parent.Command("version", "Show the Go version used by the project").
-		Action(func(*kingpin.ParseContext) error {
-			detectedVersion, err := project.DetectVersion(gowrapHome, wd)
-			if err != nil {
-				return err
-			}
+	cmd := parent.Command("version", "Show the Go version used by the project")
+	output := outputFlag(cmd)
 
-			var message string
-			switch {
-			case detectedVersion.IsDefined() && detectedVersion.IsAvailable():
-				message = fmt.Sprintf("%s (specific version to use: %s)", detectedVersion.Defined, detectedVersion.Installed)
-			case detectedVersion.IsDefined():
-				message = fmt.Sprintf("%s (no compatible installed version found)", detectedVersion.Defined)
-			case detectedVersion.IsAvailable():
-				message = detectedVersion.Installed
-			default:
-				message = "no versions installed found"
-			}
+	cmd.Action(func(*kingpin.ParseContext) error {
+		detectedVersion, err := project.DetectVersion(gowrapHome, wd)
+		if *output == outputJSON && customerrors.IsNotFound(err) && detectedVersion != nil {
+			// no versions installed is reported with an empty installed version
+			return printProjectVersionJSON(gowrapHome, detectedVersion)
+		} else if err != nil {
+			return err
+		} else if *output == outputJSON {
+			return printProjectVersionJSON(gowrapHome, detectedVersion)
+		}
 
-			fmt.Println(message)
-			return nil
-		})
+		var message string
+		switch {
+		case detectedVersion.IsDefined() && detectedVersion.IsAvailable():
+			message = fmt.Sprintf("%s (specific version to use: %s, source: %s)", detectedVersion.Defined, detectedVersion.Installed, detectedVersion.Source)
+		case detectedVersion.IsDefined():
+			message = fmt.Sprintf("%s (no compatible installed version found, source: %s)", detectedVersion.Defined, detectedVersion.Source)
+		case detectedVersion.IsAvailable():
+			message = detectedVersion.Installed
+		default:
+			message = "no versions installed found"
+		}
+
+		fmt.Println(message)
+		if len(detectedVersion.WorkspaceRoot) > 0 {
+			fmt.Printf("workspace root: %s\n", detectedVersion.WorkspaceRoot)
+		}
+		return nil
+	})
+}
+
+func printProjectVersionJSON(gowrapHome string, detectedVersion *project.Version) error {
+	version := projectVersion{
+		Defined:       detectedVersion.Defined,
+		Installed:     detectedVersion.Installed,
+		Source:        detectedVersion.Source,
+		SourcePath:    detectedVersion.SourcePath,
+		ProjectRoot:   detectedVersion.ProjectRoot,
+		WorkspaceRoot: detectedVersion.WorkspaceRoot,
+	}
+
+	if detectedVersion.IsAvailable() {
+		versionsDir, err := versions.GetVersionsDir(gowrapHome)
+		if err != nil {
+			return err
+		}
+		version.InstallPath = filepath.Join(versionsDir, detectedVersion.Installed)
+	}
+
+	return printJSON(version)
 }
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/versions/versionstest"
+)
+
+func Test_projectVersionCommand_JSONOutput(t *testing.T) {
+	t.Setenv("GOWORK", "")
+
+	testCases := map[string]struct {
+		files     map[string]string
+		installed []string
+
+		expected func(gowrapHome, projectRoot string) projectVersion
+	}{
+		"VersionFile": {
+			files: map[string]string{
+				"go.mod":      "module foo\n\ngo 1.21\n",
+				".go-version": "1.21\n",
+			},
+			installed: []string{"1.21.5", "1.21.6"},
+			expected: func(gowrapHome, projectRoot string) projectVersion {
+				return projectVersion{
+					Defined:     "1.21",
+					Installed:   "1.21.6",
+					InstallPath: filepath.Join(gowrapHome, "versions", "1.21.6"),
+					Source:      ".go-version",
+					SourcePath:  filepath.Join(projectRoot, ".go-version"),
+					ProjectRoot: projectRoot,
+				}
+			},
+		},
+		"GoDirective": {
+			files:     map[string]string{"go.mod": "module foo\n\ngo 1.21\n"},
+			installed: []string{"1.21.5"},
+			expected: func(gowrapHome, projectRoot string) projectVersion {
+				return projectVersion{
+					Defined:     "1.21",
+					Installed:   "1.21.5",
+					InstallPath: filepath.Join(gowrapHome, "versions", "1.21.5"),
+					Source:      "go",
+					SourcePath:  filepath.Join(projectRoot, "go.mod"),
+					ProjectRoot: projectRoot,
+				}
+			},
+		},
+		"NoVersionsInstalled": {
+			files: map[string]string{"go.mod": "module foo\n\ngo 1.21\n\ntoolchain go1.21.6\n"},
+			expected: func(gowrapHome, projectRoot string) projectVersion {
+				return projectVersion{
+					Defined:     "1.21.6",
+					Source:      "toolchain",
+					SourcePath:  filepath.Join(projectRoot, "go.mod"),
+					ProjectRoot: projectRoot,
+				}
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			gowrapHome := t.TempDir()
+			for _, version := range testCase.installed {
+				versionstest.CreateInstalledVersion(t, gowrapHome, version, time.Now())
+			}
+
+			projectRoot, err := filepath.EvalSymlinks(t.TempDir())
+			require.NoError(t, err)
+			for name, content := range testCase.files {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(projectRoot, name), []byte(content), 0600))
+			}
+			wd := filepath.Join(projectRoot, "pkg")
+			require.NoError(t, os.MkdirAll(wd, 0755))
+
+			app := kingpin.New("gowrap", "")
+			newProjectCommand(app, gowrapHome, wd)
+
+			output := captureStdout(t, func() {
+				_, err := app.Parse([]string{"project", "version", "--output", "json"})
+				require.NoError(t, err)
+			})
+
+			var actual projectVersion
+			require.NoError(t, json.Unmarshal(output, &actual))
+			assert.Equal(t, testCase.expected(gowrapHome, projectRoot), actual)
+		})
+	}
+}
+
+func captureStdout(t *testing.T, f func()) []byte {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	f()
+	require.NoError(t, writer.Close())
+
+	output, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	return output
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/xabierlaiseca/gowrap/cmd/common"
+	"github.com/xabierlaiseca/gowrap/pkg/config"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+func newPruneCommand(app *kingpin.Application, gowrapHome string) {
+	cmd := app.Command("prune", "Removes installed go versions not kept by the retention policy").
+		HelpLong("Versions are kept if they are among the latest patches of their minor version or if they were used recently. " +
+			"The default version and the versions used by known projects are never removed.")
+
+	var keepPatchesSet, keepUsedDaysSet bool
+	keepPatches := cmd.Flag("keep-patches", "number of latest versions to keep per minor version, configured value used if not provided").
+		IsSetByUser(&keepPatchesSet).
+		Int()
+	keepUsedDays := cmd.Flag("keep-used-days", "keep versions used within this number of days, configured value used if not provided").
+		IsSetByUser(&keepUsedDaysSet).
+		Int()
+	dryRun := cmd.Flag("dry-run", "print the versions that would be removed without removing them").
+		Bool()
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			return validatePrunePolicy(*keepPatches, *keepUsedDays)
+		}).
+		Action(func(*kingpin.ParseContext) error {
+			c, err := config.Load(gowrapHome)
+			if err != nil {
+				return err
+			}
+
+			policy := common.PrunePolicyFor(c)
+			if keepPatchesSet {
+				policy.KeepPatches = *keepPatches
+			}
+			if keepUsedDaysSet {
+				policy.KeepUsedWithin = time.Duration(*keepUsedDays) * 24 * time.Hour
+			}
+
+			pruned, err := common.PruneVersions(gowrapHome, policy, *dryRun)
+			for _, version := range pruned {
+				if *dryRun {
+					fmt.Printf("Would remove version %s\n", version)
+				} else {
+					fmt.Printf("Removed version %s\n", version)
+				}
+			}
+			return err
+		})
+}
+
+func validatePrunePolicy(keepPatches, keepUsedDays int) error {
+	if keepPatches < 0 {
+		return customerrors.Errorf("invalid number of patches to keep: %d", keepPatches)
+	} else if keepUsedDays < 0 {
+		return customerrors.Errorf("invalid number of days to keep used versions: %d", keepUsedDays)
+	}
+	return nil
+}
@@ -17,12 +17,20 @@ func RunCli(gowrapVersion, gowrapHome, wd string, args []string) error {
 	})
 	app.HelpFlag.Help("Show context-sensitive help")
 
-	newConfigureCommand(app, gowrapHome)
+	newCacheCommand(app, gowrapHome)
+	newConfigureCommand(app, gowrapHome, wd)
+	newDoctorCommand(app, gowrapVersion, gowrapHome)
+	newEnvCommand(app, gowrapHome, wd)
+	newExecCommand(app, gowrapHome)
 	newInstallCommand(app, gowrapHome)
 	newListCommand(app, gowrapHome)
+	newOutdatedCommand(app, gowrapHome, wd)
 	newProjectCommand(app, gowrapHome, wd)
+	newPruneCommand(app, gowrapHome)
 	newUninstallCommand(app, gowrapHome)
-	newVersionsFileCommand(app)
+	newUpgradeCommand(app, gowrapHome)
+	newVerifyCommand(app, gowrapHome)
+	newVersionsFileCommand(app, gowrapHome)
 
 	app.Command("version", "Prints the gowrap version").
 		Action(func(context *kingpin.ParseContext) error {
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/xabierlaiseca/gowrap/cmd/common"
+	"github.com/xabierlaiseca/gowrap/pkg/config"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	"github.com/xabierlaiseca/gowrap/pkg/versions"
+)
+
+func newUpgradeCommand(app *kingpin.Application, gowrapHome string) {
+	cmd := app.Command("upgrade", "Installs the latest patch of installed minor versions").
+		HelpLong("Installs the latest available version for each given prefix, or for each installed minor version if none given.")
+	prefixes := cmd.Arg("prefix", "version prefixes to upgrade, all installed minor versions if not provided").
+		HintAction(installedVersionCompletion).
+		Strings()
+	removeSuperseded := cmd.Flag("remove-superseded", "remove the older patches of upgraded minor versions, except the ones used by known projects").
+		Bool()
+	updateDefault := cmd.Flag("update-default", "update the default version to its latest patch if it is an exact version").
+		Bool()
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			for _, prefix := range *prefixes {
+				if !semver.IsValid(prefix) || semver.IsDevel(prefix) {
+					return customerrors.Errorf("invalid version prefix provided: %s", prefix)
+				}
+			}
+			return nil
+		}).
+		Action(func(*kingpin.ParseContext) error {
+			upgrades, err := versions.FindUpgrades(gowrapHome, *prefixes...)
+			if err != nil {
+				return err
+			}
+
+			var superseded []string
+			for _, upgrade := range upgrades {
+				if err := installVersion(gowrapHome, upgrade.Latest); err != nil {
+					return err
+				}
+				superseded = append(superseded, upgrade.Superseded...)
+			}
+
+			if *updateDefault {
+				if err := upgradeDefaultVersion(gowrapHome, upgrades); err != nil {
+					return err
+				}
+			}
+
+			if !*removeSuperseded {
+				return nil
+			}
+
+			removed, kept, err := common.RemoveUnprotected(gowrapHome, superseded)
+			for _, version := range removed {
+				fmt.Printf("Removed version %s\n", version)
+			}
+			for _, version := range kept {
+				fmt.Printf("Kept version %s, it is used by default or by known projects\n", version)
+			}
+			return err
+		})
+}
+
+// upgradeDefaultVersion sets the default version to the latest version
+// superseding it, if the default version is an exact version.
+func upgradeDefaultVersion(gowrapHome string, upgrades []versions.Upgrade) error {
+	c, err := config.Load(gowrapHome)
+	if err != nil {
+		return err
+	} else if !semver.IsFullVersion(c.DefaultVersion) {
+		return nil
+	}
+
+	for _, upgrade := range upgrades {
+		if versions.IsSupersededBy(c.DefaultVersion, upgrade.Latest) {
+			fmt.Printf("Updating default version from %s to %s\n", c.DefaultVersion, upgrade.Latest)
+			return versions.SetDefaultVersion(gowrapHome, upgrade.Latest)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/config"
+	"github.com/xabierlaiseca/gowrap/pkg/versions"
+	"github.com/xabierlaiseca/gowrap/pkg/versions/versionstest"
+)
+
+func Test_upgradeDefaultVersion(t *testing.T) {
+	upgrades := []versions.Upgrade{
+		{Prefix: "1.20", Latest: "1.20.14"},
+		{Prefix: "1.21", Latest: "1.21.8"},
+	}
+
+	testCases := map[string]struct {
+		defaultVersion  string
+		expectedVersion string
+	}{
+		"SupersededVersion": {
+			defaultVersion:  "1.21.5",
+			expectedVersion: "1.21.8",
+		},
+		"SupersededPreRelease": {
+			defaultVersion:  "1.21rc2",
+			expectedVersion: "1.21.8",
+		},
+		"LatestVersion": {
+			defaultVersion:  "1.21.8",
+			expectedVersion: "1.21.8",
+		},
+		"VersionWithoutUpgrade": {
+			defaultVersion:  "1.22.0",
+			expectedVersion: "1.22.0",
+		},
+		"Prefix": {
+			defaultVersion:  "1.21",
+			expectedVersion: "1.21",
+		},
+		"NoDefaultVersion": {
+			defaultVersion:  "",
+			expectedVersion: "",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			gowrapHome := t.TempDir()
+			versionstest.CreateInstalledVersion(t, gowrapHome, "1.20.14", time.Now())
+			versionstest.CreateInstalledVersion(t, gowrapHome, "1.21.8", time.Now())
+
+			c, err := config.Load(gowrapHome)
+			require.NoError(t, err)
+			c.DefaultVersion = testCase.defaultVersion
+			require.NoError(t, c.Save())
+
+			require.NoError(t, upgradeDefaultVersion(gowrapHome, upgrades))
+
+			c, err = config.Load(gowrapHome)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedVersion, c.DefaultVersion)
+		})
+	}
+}
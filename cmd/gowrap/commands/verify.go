@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	"github.com/xabierlaiseca/gowrap/pkg/versions"
+)
+
+func newVerifyCommand(app *kingpin.Application, gowrapHome string) {
+	cmd := app.Command("verify", "Verifies installed go versions against the manifest recorded when installed").
+		HelpLong("Reports added, changed and missing files of each version and fails if any version doesn't match its manifest.")
+	versionsToVerify := cmd.Arg("version", "versions to verify, all installed versions if not provided").
+		HintAction(installedVersionCompletion).
+		Strings()
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			for _, version := range *versionsToVerify {
+				if !semver.IsValid(version) {
+					return customerrors.Errorf("invalid version provided: %s", version)
+				}
+			}
+			return nil
+		}).
+		Action(func(*kingpin.ParseContext) error {
+			toVerify := *versionsToVerify
+			if len(toVerify) == 0 {
+				installed, err := versions.ListInstalled(gowrapHome)
+				if err != nil {
+					return err
+				}
+				toVerify = installed
+			}
+
+			failed := 0
+			for _, version := range toVerify {
+				if !verifyVersion(gowrapHome, version) {
+					failed++
+				}
+			}
+
+			if failed > 0 {
+				return customerrors.Errorf("%d version(s) failed verification", failed)
+			}
+			return nil
+		})
+}
+
+// verifyVersion prints the verification of the given version and returns
+// whether it succeeded.
+func verifyVersion(gowrapHome, version string) bool {
+	verification, err := versions.Verify(gowrapHome, version)
+	switch {
+	case customerrors.IsNotFound(err):
+		fmt.Printf("%s: no manifest recorded, reinstall it to record one\n", version)
+		return false
+	case err != nil:
+		fmt.Printf("%s: %v\n", version, err)
+		return false
+	case verification.IsValid():
+		fmt.Printf("%s: OK\n", version)
+		return true
+	}
+
+	fmt.Printf("%s: FAILED\n", version)
+	printFiles("added", verification.Added)
+	printFiles("changed", verification.Changed)
+	printFiles("missing", verification.Missing)
+	return false
+}
+
+func printFiles(kind string, files []string) {
+	for _, f := range files {
+		fmt.Printf("  %s: %s\n", kind, f)
+	}
+}
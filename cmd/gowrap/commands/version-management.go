@@ -10,7 +10,51 @@ import (
 )
 
 func newInstallCommand(app *kingpin.Application, gowrapHome string) {
-	versionManagementCommand(app, gowrapHome, "install", notInstalledVersionCompletion, installVersion)
+	cmd := app.Command("install", "install go version")
+	version := cmd.Arg("version", "version to install").
+		HintAction(notInstalledVersionCompletion).
+		String()
+	fromArchive := cmd.Flag("from-archive", "install from a local go archive instead of downloading it").
+		ExistingFile()
+	archiveVersion := cmd.Flag("version", "version of the go archive, detected from the archive if not provided").
+		String()
+	fromSource := cmd.Flag("from-source", "build and install go from a git repository, either a local checkout or a URL").
+		String()
+	ref := cmd.Flag("ref", "git reference to build, the default branch is built as tip if not provided").
+		String()
+
+	cmd.
+		Validate(func(*kingpin.CmdClause) error {
+			switch {
+			case len(*fromSource) > 0 && (len(*version) > 0 || len(*fromArchive) > 0 || len(*archiveVersion) > 0):
+				return customerrors.Error("--from-source can't be used with a version or --from-archive")
+			case len(*fromSource) > 0:
+				return nil
+			case len(*ref) > 0:
+				return customerrors.Error("--ref can only be used with --from-source")
+			case len(*fromArchive) > 0 && len(*version) > 0:
+				return customerrors.Error("version argument can't be used with --from-archive, use --version instead")
+			case len(*fromArchive) > 0 && len(*archiveVersion) > 0 && !semver.IsReleaseName(*archiveVersion):
+				return customerrors.Errorf("invalid archive version provided: %s", *archiveVersion)
+			case len(*fromArchive) > 0:
+				return nil
+			case len(*archiveVersion) > 0:
+				return customerrors.Error("--version can only be used with --from-archive")
+			case len(*version) == 0:
+				return customerrors.Error("required argument 'version' not provided")
+			case !semver.IsValid(*version):
+				return customerrors.Errorf("invalid version provided: %s", *version)
+			}
+			return nil
+		}).
+		Action(func(*kingpin.ParseContext) error {
+			if len(*fromSource) > 0 {
+				return installFromSource(gowrapHome, *fromSource, *ref)
+			} else if len(*fromArchive) > 0 {
+				return installFromArchive(gowrapHome, *fromArchive, *archiveVersion)
+			}
+			return installVersion(gowrapHome, *version)
+		})
 }
 
 func newUninstallCommand(app *kingpin.Application, gowrapHome string) {
@@ -45,3 +89,23 @@ func installVersion(gowrapHome string, prefix string) error {
 
 	return nil
 }
+
+func installFromArchive(gowrapHome, archivePath, version string) error {
+	if installedVersion, installed, err := versions.InstallFromArchive(gowrapHome, archivePath, version); err != nil {
+		return err
+	} else if !installed {
+		fmt.Printf("version '%s' was already installed\n", installedVersion)
+	}
+
+	return nil
+}
+
+func installFromSource(gowrapHome, repository, ref string) error {
+	if installedVersion, installed, err := versions.InstallFromSource(gowrapHome, repository, ref); err != nil {
+		return err
+	} else if !installed {
+		fmt.Printf("version '%s' was already installed\n", installedVersion)
+	}
+
+	return nil
+}
@@ -1,14 +1,22 @@
 package commands
 
 import (
+	"crypto/ed25519"
+	"fmt"
+	"os"
+
 	"github.com/alecthomas/kingpin"
+	"github.com/xabierlaiseca/gowrap/pkg/versions"
 	"github.com/xabierlaiseca/gowrap/pkg/versionsfile"
 )
 
-func newVersionsFileCommand(parent *kingpin.Application) {
+const signingKeyEnvVar = "GOWRAP_VERSIONS_FILE_SIGNING_KEY"
+
+func newVersionsFileCommand(parent *kingpin.Application, gowrapHome string) {
 	cmd := parent.Command("versions-file", "commands to manage versions file")
 	newVersionsFileGenerateCommand(cmd)
-	newVersionsFileDownloadCommand(cmd)
+	newVersionsFileKeygenCommand(cmd)
+	newVersionsFileDownloadCommand(cmd, gowrapHome)
 }
 
 func newVersionsFileGenerateCommand(parent *kingpin.CmdClause) {
@@ -19,15 +27,37 @@ func newVersionsFileGenerateCommand(parent *kingpin.CmdClause) {
 		String()
 
 	cmd.Action(func(*kingpin.ParseContext) error {
-		return versionsfile.Generate(*file)
+		var signingKey ed25519.PrivateKey
+		if encodedSeed := os.Getenv(signingKeyEnvVar); len(encodedSeed) > 0 {
+			var err error
+			if signingKey, err = versionsfile.ParseSigningKey(encodedSeed); err != nil {
+				return err
+			}
+		}
+
+		return versionsfile.Generate(*file, signingKey)
 	})
 }
 
-func newVersionsFileDownloadCommand(parent *kingpin.CmdClause) {
+func newVersionsFileKeygenCommand(parent *kingpin.CmdClause) {
+	parent.Command("keygen", "").Hidden().
+		Action(func(*kingpin.ParseContext) error {
+			signingKey, publicKey, err := versionsfile.GenerateSigningKey()
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("signing key (keep secret, e.g. as the %s of CI): %s\n", signingKeyEnvVar, signingKey)
+			fmt.Printf("public key (embed in pkg/versionsfile/signature.go): %s\n", publicKey)
+			return nil
+		})
+}
+
+func newVersionsFileDownloadCommand(parent *kingpin.CmdClause, gowrapHome string) {
 	cmd := parent.Command("download", "Downloads latest versions file")
 
 	cmd.Action(func(*kingpin.ParseContext) error {
-		_, err := versionsfile.DownloadToCache()
+		_, err := versions.DownloadAvailable(gowrapHome)
 		return err
 	})
 }
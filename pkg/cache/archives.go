@@ -0,0 +1,244 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xabierlaiseca/gowrap/pkg/util/file"
+)
+
+const relArchivesDir = "archives"
+
+// Archive is an archive stored in the cache, addressed by its checksum.
+type Archive struct {
+	Name              string
+	Checksum          string
+	ChecksumAlgorithm string
+	Path              string
+	Size              int64
+	LastUsed          time.Time
+}
+
+// ArchiveLimits defines the limits of the archives stored in the cache. Zero
+// values mean no limit.
+type ArchiveLimits struct {
+	MaxSize int64
+	MaxAge  time.Duration
+}
+
+// GetArchive returns the path of the archive with the given name and checksum
+// if stored in the cache. Its checksum is verified before returning it, and
+// corrupted archives are removed.
+func GetArchive(name, checksum, algorithm string) (string, bool, error) {
+	rootDir, err := getRootDir()
+	if err != nil {
+		return "", false, err
+	}
+
+	return getArchive(rootDir, name, checksum, algorithm)
+}
+
+func getArchive(rootDir, name, checksum, algorithm string) (string, bool, error) {
+	archivePath := buildArchivePath(rootDir, name, checksum, algorithm)
+	if _, err := os.Stat(archivePath); os.IsNotExist(err) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	if actualChecksum, err := file.Checksum(archivePath, algorithm); err != nil {
+		return "", false, err
+	} else if actualChecksum != checksum {
+		logrus.Warningf("cached archive %s is corrupted, removing it", name)
+		return "", false, os.RemoveAll(filepath.Dir(archivePath))
+	}
+
+	now := time.Now()
+	return archivePath, true, os.Chtimes(archivePath, now, now)
+}
+
+// NewArchivePath returns the path to store the archive with the given name and
+// checksum in the cache.
+func NewArchivePath(name, checksum, algorithm string) (string, error) {
+	rootDir, err := getRootDir()
+	if err != nil {
+		return "", err
+	}
+
+	archivePath := buildArchivePath(rootDir, name, checksum, algorithm)
+	return archivePath, os.MkdirAll(filepath.Dir(archivePath), 0755)
+}
+
+// ListArchives returns the archives stored in the cache, most recently used
+// first.
+func ListArchives() ([]Archive, error) {
+	rootDir, err := getRootDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return listArchives(rootDir)
+}
+
+func listArchives(rootDir string) ([]Archive, error) {
+	archivesDir := filepath.Join(rootDir, relArchivesDir)
+	entries, err := ioutil.ReadDir(archivesDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var archives []Archive
+	for _, entry := range entries {
+		algorithm, checksum, valid := splitArchiveKey(entry.Name())
+		if !entry.IsDir() || !valid {
+			continue
+		}
+
+		files, err := ioutil.ReadDir(filepath.Join(archivesDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range files {
+			if f.Mode().IsRegular() && !strings.HasSuffix(f.Name(), file.PartialDownloadSuffix) {
+				archives = append(archives, Archive{
+					Name:              f.Name(),
+					Checksum:          checksum,
+					ChecksumAlgorithm: algorithm,
+					Path:              filepath.Join(archivesDir, entry.Name(), f.Name()),
+					Size:              f.Size(),
+					LastUsed:          f.ModTime(),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(archives, func(i, j int) bool {
+		return archives[i].LastUsed.After(archives[j].LastUsed)
+	})
+
+	return archives, nil
+}
+
+// VerifyArchives verifies the checksums of the archives stored in the cache,
+// removing the corrupted ones. It returns the corrupted archives.
+func VerifyArchives() ([]Archive, error) {
+	archives, err := ListArchives()
+	if err != nil {
+		return nil, err
+	}
+
+	var corrupted []Archive
+	for _, archive := range archives {
+		checksum, err := file.Checksum(archive.Path, archive.ChecksumAlgorithm)
+		if err != nil {
+			return corrupted, err
+		}
+
+		if checksum != archive.Checksum {
+			corrupted = append(corrupted, archive)
+			if err := os.RemoveAll(filepath.Dir(archive.Path)); err != nil {
+				return corrupted, err
+			}
+		}
+	}
+
+	return corrupted, nil
+}
+
+// PruneArchives removes the archives not used within the maximum age and, if
+// still above the maximum size, the least recently used ones. Partial
+// downloads older than the maximum age are removed too. It returns the removed
+// archives.
+func PruneArchives(limits ArchiveLimits) ([]Archive, error) {
+	rootDir, err := getRootDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return pruneArchives(rootDir, limits)
+}
+
+func pruneArchives(rootDir string, limits ArchiveLimits) ([]Archive, error) {
+	archives, err := listArchives(rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var size int64
+	var pruned []Archive
+	for _, archive := range archives {
+		expired := limits.MaxAge > 0 && time.Since(archive.LastUsed) > limits.MaxAge
+		if !expired && (limits.MaxSize <= 0 || size+archive.Size <= limits.MaxSize) {
+			size += archive.Size
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Dir(archive.Path)); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, archive)
+	}
+
+	return pruned, removeStalePartialArchives(rootDir, limits.MaxAge)
+}
+
+// ClearArchives removes all the archives stored in the cache, including partial
+// downloads. It returns the removed archives.
+func ClearArchives() ([]Archive, error) {
+	rootDir, err := getRootDir()
+	if err != nil {
+		return nil, err
+	}
+
+	archives, err := listArchives(rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return archives, os.RemoveAll(filepath.Join(rootDir, relArchivesDir))
+}
+
+func removeStalePartialArchives(rootDir string, maxAge time.Duration) error {
+	if maxAge <= 0 {
+		return nil
+	}
+
+	partials, err := filepath.Glob(filepath.Join(rootDir, relArchivesDir, "*", "*"+file.PartialDownloadSuffix))
+	if err != nil {
+		return err
+	}
+
+	for _, partial := range partials {
+		if stat, err := os.Stat(partial); err == nil && time.Since(stat.ModTime()) > maxAge {
+			if err := os.RemoveAll(filepath.Dir(partial)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// buildArchivePath returns the path of an archive, stored in a directory named
+// after its checksum so archives are only reused if their content matches.
+func buildArchivePath(rootDir, name, checksum, algorithm string) string {
+	key := strings.ToLower(algorithm) + "-" + strings.ToLower(checksum)
+	return filepath.Join(rootDir, relArchivesDir, key, filepath.Base(name))
+}
+
+func splitArchiveKey(key string) (string, string, bool) {
+	split := strings.SplitN(key, "-", 2)
+	if len(split) != 2 || len(split[0]) == 0 || len(split[1]) == 0 {
+		return "", "", false
+	}
+
+	return split[0], split[1], true
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetArchive(t *testing.T) {
+	testCases := map[string]struct {
+		stored        []byte
+		expectedFound bool
+	}{
+		"NotCached": {},
+		"Cached": {
+			stored:        []byte("go archive"),
+			expectedFound: true,
+		},
+		"Corrupted": {
+			stored:        []byte("tampered go archive"),
+			expectedFound: false,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			rootDir := t.TempDir()
+			checksum := sha256Of([]byte("go archive"))
+			archivePath := buildArchivePath(rootDir, "go1.21.5.linux-amd64.tar.gz", checksum, "SHA256")
+			if testCase.stored != nil {
+				storeArchive(t, archivePath, testCase.stored, time.Now().Add(-time.Hour))
+			}
+
+			actualPath, found, err := getArchive(rootDir, "go1.21.5.linux-amd64.tar.gz", checksum, "SHA256")
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedFound, found)
+			if testCase.expectedFound {
+				assert.Equal(t, archivePath, actualPath)
+				stat, err := os.Stat(archivePath)
+				require.NoError(t, err)
+				assert.WithinDuration(t, time.Now(), stat.ModTime(), time.Minute)
+			} else {
+				assert.NoFileExists(t, archivePath)
+			}
+		})
+	}
+}
+
+func Test_PruneArchives(t *testing.T) {
+	testCases := map[string]struct {
+		limits         ArchiveLimits
+		expectedPruned []string
+	}{
+		"NoLimits": {},
+		"MaxAge": {
+			limits:         ArchiveLimits{MaxAge: 10 * 24 * time.Hour},
+			expectedPruned: []string{"old.tar.gz"},
+		},
+		"MaxSize": {
+			limits:         ArchiveLimits{MaxSize: 15},
+			expectedPruned: []string{"older.tar.gz", "old.tar.gz"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			rootDir := t.TempDir()
+			for name, lastUsed := range map[string]time.Time{
+				"recent.tar.gz": time.Now(),
+				"older.tar.gz":  time.Now().Add(-24 * time.Hour),
+				"old.tar.gz":    time.Now().Add(-30 * 24 * time.Hour),
+			} {
+				content := []byte(name)
+				storeArchive(t, buildArchivePath(rootDir, name, sha256Of(content), "sha256"), content, lastUsed)
+			}
+
+			pruned, err := pruneArchives(rootDir, testCase.limits)
+			require.NoError(t, err)
+
+			var prunedNames []string
+			for _, archive := range pruned {
+				prunedNames = append(prunedNames, archive.Name)
+			}
+			assert.Equal(t, testCase.expectedPruned, prunedNames)
+
+			remaining, err := listArchives(rootDir)
+			require.NoError(t, err)
+			assert.Len(t, remaining, 3-len(testCase.expectedPruned))
+		})
+	}
+}
+
+func storeArchive(t *testing.T, archivePath string, content []byte, lastUsed time.Time) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(archivePath), 0755))
+	require.NoError(t, ioutil.WriteFile(archivePath, content, 0600))
+	require.NoError(t, os.Chtimes(archivePath, lastUsed, lastUsed))
+}
+
+func sha256Of(content []byte) string {
+	checksum := sha256.Sum256(content)
+	return hex.EncodeToString(checksum[:])
+}
@@ -11,6 +11,7 @@ import (
 )
 
 const (
+	cacheDirEnvVar  = "GOWRAP_CACHE_DIR"
 	relCacheRootDir = "gowrap"
 	relMetadataDir  = "metadata"
 	relObjectsDir   = "objects"
@@ -86,6 +87,37 @@ func set(rootDir, relpath string, content []byte, expiresIn time.Duration) error
 	return storeCacheMetadata(cachedObjectsMetadataFile, metadata)
 }
 
+// CheckMetadata returns an error if the metadata of cached objects can't be
+// read.
+func CheckMetadata() error {
+	rootDir, err := getRootDir()
+	if err != nil {
+		return err
+	}
+
+	_, err = readCacheMetadata(buildCachedObjectsMetadataFile(rootDir))
+	return err
+}
+
+// ResetMetadata removes the cached objects and rebuilds their metadata empty.
+// Cached go archives are kept, as they don't depend on it.
+func ResetMetadata() error {
+	rootDir, err := getRootDir()
+	if err != nil {
+		return err
+	}
+
+	return resetMetadata(rootDir)
+}
+
+func resetMetadata(rootDir string) error {
+	if err := os.RemoveAll(filepath.Join(rootDir, relObjectsDir)); err != nil {
+		return err
+	}
+
+	return storeCacheMetadata(buildCachedObjectsMetadataFile(rootDir), make(map[string]time.Time))
+}
+
 func readCacheMetadata(cachedObjectsMetadataFile string) (map[string]time.Time, error) {
 	content, err := ioutil.ReadFile(cachedObjectsMetadataFile)
 	if os.IsNotExist(err) {
@@ -112,7 +144,13 @@ func storeCacheMetadata(cachedObjectsMetadataFile string, metadata map[string]ti
 	return ioutil.WriteFile(cachedObjectsMetadataFile, bytes, 0600)
 }
 
+// getRootDir returns the directory set through GOWRAP_CACHE_DIR, or the gowrap
+// directory in the user cache directory otherwise.
 func getRootDir() (string, error) {
+	if rootDir := os.Getenv(cacheDirEnvVar); len(rootDir) > 0 {
+		return rootDir, nil
+	}
+
 	userCacheDir, err := os.UserCacheDir()
 	if err != nil {
 		return "", err
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -80,3 +81,55 @@ func verifyExpires(t *testing.T, rootDir, relpath string) {
 
 	assert.Eventually(t, cacheEntryNotExists, 1*time.Second, 50*time.Millisecond)
 }
+
+func Test_ResetMetadata(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "test-cache-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	require.NoError(t, set(tmpDir, "test.txt", []byte("content"), time.Hour))
+
+	metadataFile := buildCachedObjectsMetadataFile(tmpDir)
+	require.NoError(t, ioutil.WriteFile(metadataFile, []byte("{broken"), 0600))
+	_, err = get(tmpDir, "test.txt")
+	require.Error(t, err)
+
+	require.NoError(t, resetMetadata(tmpDir))
+
+	content, err := get(tmpDir, "test.txt")
+	require.NoError(t, err)
+	assert.Nil(t, content)
+
+	require.NoError(t, set(tmpDir, "test.txt", []byte("content"), time.Hour))
+	content, err = get(tmpDir, "test.txt")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("content"), content)
+}
+
+func Test_getRootDir(t *testing.T) {
+	userCacheDir, err := os.UserCacheDir()
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		cacheDir string
+		expected string
+	}{
+		"UserCacheDir": {
+			expected: filepath.Join(userCacheDir, "gowrap"),
+		},
+		"CacheDirOverride": {
+			cacheDir: filepath.Join(os.TempDir(), "gowrap-cache"),
+			expected: filepath.Join(os.TempDir(), "gowrap-cache"),
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			t.Setenv(cacheDirEnvVar, testCase.cacheDir)
+
+			actual, err := getRootDir()
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
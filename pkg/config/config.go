@@ -5,10 +5,17 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
+
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
 )
 
 const (
-	configFileName = "config.json"
+	configFileName   = "config.json"
+	backupFileSuffix = ".bak"
+
+	mirrorEnvVar = "GOWRAP_MIRROR"
 )
 
 const (
@@ -18,6 +25,21 @@ const (
 
 	SelfUpgradesEnabled  = "enabled"
 	SelfUpgradesDisabled = "disabled"
+
+	SignatureVerificationEnabled  = "enabled"
+	SignatureVerificationDisabled = "disabled"
+
+	DefaultDownloadTimeout = 5 * time.Minute
+	DefaultDownloadRetries = 3
+
+	DefaultArchiveCacheMaxSizeMB  = 2048
+	DefaultArchiveCacheMaxAgeDays = 90
+
+	AutoPruneEnabled  = "enabled"
+	AutoPruneDisabled = "disabled"
+
+	DefaultPruneKeepPatches  = 1
+	DefaultPruneKeepUsedDays = 30
 )
 
 type Configuration struct {
@@ -25,6 +47,40 @@ type Configuration struct {
 	DefaultVersion string `json:"defaultVersion,omitempty"`
 	AutoInstall    string `json:"autoInstall,omitempty"`
 	SelfUpgrade    string `json:"selfUpgrade,omitempty"`
+	// VersionFiles contains the version files to read versions from, sorted by
+	// precedence. Default version files are used if empty.
+	VersionFiles []string `json:"versionFiles,omitempty"`
+	// Mirror is the base URL of a mirror serving the versions file and go
+	// archives, used instead of the default locations if set.
+	Mirror string `json:"mirror,omitempty"`
+	// SignatureVerification defines whether the signature of downloaded
+	// versions files is verified.
+	SignatureVerification string `json:"signatureVerification,omitempty"`
+	// DownloadTimeout is the maximum duration of each attempt to download a go
+	// archive, as accepted by time.ParseDuration.
+	DownloadTimeout string `json:"downloadTimeout,omitempty"`
+	// DownloadRetries is the number of times a go archive download is retried
+	// after transient errors.
+	DownloadRetries int `json:"downloadRetries"`
+	// ArchiveCacheMaxSizeMB is the maximum size in megabytes of the cached go
+	// archives, 0 means no limit.
+	ArchiveCacheMaxSizeMB int64 `json:"archiveCacheMaxSizeMB"`
+	// ArchiveCacheMaxAgeDays is the maximum number of days a cached go archive
+	// is kept since last used, 0 means no limit.
+	ArchiveCacheMaxAgeDays int `json:"archiveCacheMaxAgeDays"`
+	// AutoPrune defines whether installed versions are pruned after automatic
+	// installs.
+	AutoPrune string `json:"autoPrune,omitempty"`
+	// PruneKeepPatches is the number of latest versions kept per minor line
+	// when pruning.
+	PruneKeepPatches int `json:"pruneKeepPatches"`
+	// PruneKeepUsedDays keeps the versions used within the given number of days
+	// when pruning.
+	PruneKeepUsedDays int `json:"pruneKeepUsedDays"`
+	// DirectoryVersions contains the versions to use in directories without a
+	// version defined by a project, indexed by the absolute path of the
+	// directory. They apply to subdirectories too.
+	DirectoryVersions map[string]string `json:"directoryVersions,omitempty"`
 }
 
 func Load(gowrapHome string) (*Configuration, error) {
@@ -32,6 +88,18 @@ func Load(gowrapHome string) (*Configuration, error) {
 		gowrapHome:  gowrapHome,
 		AutoInstall: AutoInstallMissing,
 		SelfUpgrade: SelfUpgradesDisabled,
+
+		SignatureVerification: SignatureVerificationEnabled,
+
+		DownloadTimeout: DefaultDownloadTimeout.String(),
+		DownloadRetries: DefaultDownloadRetries,
+
+		ArchiveCacheMaxSizeMB:  DefaultArchiveCacheMaxSizeMB,
+		ArchiveCacheMaxAgeDays: DefaultArchiveCacheMaxAgeDays,
+
+		AutoPrune:         AutoPruneDisabled,
+		PruneKeepPatches:  DefaultPruneKeepPatches,
+		PruneKeepUsedDays: DefaultPruneKeepUsedDays,
 	}
 	configFilePath := getConfigFilePath(gowrapHome)
 	if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
@@ -76,6 +144,149 @@ func (c *Configuration) Save() error {
 	return err
 }
 
+// Validate returns an error describing the first invalid value of the
+// configuration, if any.
+func (c *Configuration) Validate() error {
+	if len(c.DefaultVersion) > 0 && !semver.IsValid(c.DefaultVersion) {
+		return customerrors.Errorf("invalid default version: %s", c.DefaultVersion)
+	}
+
+	enums := []struct {
+		name    string
+		value   string
+		allowed []string
+	}{
+		{"autoInstall", c.AutoInstall, []string{AutoInstallEnabled, AutoInstallMissing, AutoInstallDisabled}},
+		{"selfUpgrade", c.SelfUpgrade, []string{SelfUpgradesEnabled, SelfUpgradesDisabled}},
+		{"signatureVerification", c.SignatureVerification, []string{SignatureVerificationEnabled, SignatureVerificationDisabled}},
+		{"autoPrune", c.AutoPrune, []string{AutoPruneEnabled, AutoPruneDisabled}},
+	}
+	for _, enum := range enums {
+		if !contains(enum.allowed, enum.value) {
+			return customerrors.Errorf("invalid %s value: %s", enum.name, enum.value)
+		}
+	}
+
+	for directory, version := range c.DirectoryVersions {
+		if !filepath.IsAbs(directory) || !semver.IsValid(version) {
+			return customerrors.Errorf("invalid version for directory %s: %s", directory, version)
+		}
+	}
+
+	if _, err := c.GetDownloadTimeout(); err != nil {
+		return err
+	}
+
+	switch {
+	case c.DownloadRetries < 0:
+		return customerrors.Errorf("invalid download retries: %d", c.DownloadRetries)
+	case c.ArchiveCacheMaxSizeMB < 0:
+		return customerrors.Errorf("invalid archive cache max size: %d", c.ArchiveCacheMaxSizeMB)
+	case c.ArchiveCacheMaxAgeDays < 0:
+		return customerrors.Errorf("invalid archive cache max age: %d", c.ArchiveCacheMaxAgeDays)
+	case c.PruneKeepPatches < 0:
+		return customerrors.Errorf("invalid number of patches to keep: %d", c.PruneKeepPatches)
+	case c.PruneKeepUsedDays < 0:
+		return customerrors.Errorf("invalid number of days to keep used versions: %d", c.PruneKeepUsedDays)
+	}
+
+	return nil
+}
+
+// Reset replaces the configuration file by the default configuration. The
+// previous file is kept next to it, and its path is returned.
+func Reset(gowrapHome string) (string, error) {
+	configFilePath := getConfigFilePath(gowrapHome)
+	backupPath := configFilePath + backupFileSuffix
+	if err := os.Rename(configFilePath, backupPath); os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return backupPath, nil
+}
+
+// SetDirectoryVersion sets the version to use in the given absolute directory
+// and its subdirectories.
+func (c *Configuration) SetDirectoryVersion(directory, version string) {
+	if c.DirectoryVersions == nil {
+		c.DirectoryVersions = make(map[string]string)
+	}
+	c.DirectoryVersions[filepath.Clean(directory)] = version
+}
+
+// UnsetDirectoryVersion removes the version set for the given absolute
+// directory, returning false if there was none.
+func (c *Configuration) UnsetDirectoryVersion(directory string) bool {
+	directory = filepath.Clean(directory)
+	if _, found := c.DirectoryVersions[directory]; !found {
+		return false
+	}
+
+	delete(c.DirectoryVersions, directory)
+	return true
+}
+
+// FindDirectoryVersion returns the version set for the given absolute
+// directory, taken from the closest directory containing it with a version
+// set. The directory the version was set for is returned too, or a not found
+// error if there is none.
+func (c *Configuration) FindDirectoryVersion(directory string) (string, string, error) {
+	for dir := filepath.Clean(directory); ; dir = filepath.Dir(dir) {
+		if version, found := c.DirectoryVersions[dir]; found {
+			return version, dir, nil
+		}
+
+		if parent := filepath.Dir(dir); parent == dir {
+			return "", "", customerrors.NotFound()
+		}
+	}
+}
+
+// GetMirror returns the mirror to download the versions file and go archives
+// from, giving preference to GOWRAP_MIRROR over the configured one. An empty
+// string means no mirror is used.
+func (c *Configuration) GetMirror() string {
+	if mirror, found := os.LookupEnv(mirrorEnvVar); found {
+		return mirror
+	}
+
+	return c.Mirror
+}
+
+// GetDownloadTimeout returns the maximum duration of each attempt to download
+// a go archive.
+func (c *Configuration) GetDownloadTimeout() (time.Duration, error) {
+	timeout, err := time.ParseDuration(c.DownloadTimeout)
+	if err != nil || timeout <= 0 {
+		return 0, customerrors.Errorf("invalid download timeout configured: %s", c.DownloadTimeout)
+	}
+
+	return timeout, nil
+}
+
+// GetArchiveCacheMaxSize returns the maximum size in bytes of the cached go
+// archives, 0 means no limit.
+func (c *Configuration) GetArchiveCacheMaxSize() int64 {
+	return c.ArchiveCacheMaxSizeMB * 1024 * 1024
+}
+
+// GetArchiveCacheMaxAge returns the maximum time a cached go archive is kept
+// since last used, 0 means no limit.
+func (c *Configuration) GetArchiveCacheMaxAge() time.Duration {
+	return time.Duration(c.ArchiveCacheMaxAgeDays) * 24 * time.Hour
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func getConfigFilePath(gowrapHome string) string {
 	return filepath.Join(gowrapHome, configFileName)
 }
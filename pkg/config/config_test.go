@@ -0,0 +1,137 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		update        func(c *Configuration)
+		expectedError bool
+	}{
+		"Defaults": {
+			update:        func(c *Configuration) {},
+			expectedError: false,
+		},
+		"ValidValues": {
+			update: func(c *Configuration) {
+				c.DefaultVersion = "1.21"
+				c.AutoInstall = AutoInstallEnabled
+				c.SelfUpgrade = SelfUpgradesEnabled
+				c.DirectoryVersions = map[string]string{filepath.Join(os.TempDir(), "project"): "1.20.4"}
+				c.DownloadTimeout = "30s"
+				c.DownloadRetries = 0
+			},
+			expectedError: false,
+		},
+		"InvalidDefaultVersion": {
+			update:        func(c *Configuration) { c.DefaultVersion = "1.x" },
+			expectedError: true,
+		},
+		"InvalidAutoInstall": {
+			update:        func(c *Configuration) { c.AutoInstall = "sometimes" },
+			expectedError: true,
+		},
+		"EmptySignatureVerification": {
+			update:        func(c *Configuration) { c.SignatureVerification = "" },
+			expectedError: true,
+		},
+		"RelativeDirectory": {
+			update:        func(c *Configuration) { c.DirectoryVersions = map[string]string{"project": "1.20.4"} },
+			expectedError: true,
+		},
+		"InvalidDirectoryVersion": {
+			update: func(c *Configuration) {
+				c.DirectoryVersions = map[string]string{filepath.Join(os.TempDir(), "project"): ""}
+			},
+			expectedError: true,
+		},
+		"InvalidDownloadTimeout": {
+			update:        func(c *Configuration) { c.DownloadTimeout = "5 minutes" },
+			expectedError: true,
+		},
+		"NonPositiveDownloadTimeout": {
+			update:        func(c *Configuration) { c.DownloadTimeout = "0s" },
+			expectedError: true,
+		},
+		"NegativeDownloadRetries": {
+			update:        func(c *Configuration) { c.DownloadRetries = -1 },
+			expectedError: true,
+		},
+		"NegativeArchiveCacheMaxSize": {
+			update:        func(c *Configuration) { c.ArchiveCacheMaxSizeMB = -1 },
+			expectedError: true,
+		},
+		"NegativePruneKeepPatches": {
+			update:        func(c *Configuration) { c.PruneKeepPatches = -1 },
+			expectedError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			c, err := Load(t.TempDir())
+			require.NoError(t, err)
+
+			testCase.update(c)
+			err = c.Validate()
+			if testCase.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_Reset(t *testing.T) {
+	testCases := map[string]struct {
+		content            string
+		expectedBackupPath bool
+	}{
+		"ExistingConfiguration": {
+			content:            `{"autoInstall": "sometimes"}`,
+			expectedBackupPath: true,
+		},
+		"MalformedConfiguration": {
+			content:            `{"autoInstall":`,
+			expectedBackupPath: true,
+		},
+		"NoConfiguration": {
+			expectedBackupPath: false,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			gowrapHome := t.TempDir()
+			configFilePath := getConfigFilePath(gowrapHome)
+			if len(testCase.content) > 0 {
+				require.NoError(t, ioutil.WriteFile(configFilePath, []byte(testCase.content), 0600))
+			}
+
+			backupPath, err := Reset(gowrapHome)
+			require.NoError(t, err)
+			assert.NoFileExists(t, configFilePath)
+
+			if !testCase.expectedBackupPath {
+				assert.Empty(t, backupPath)
+				return
+			}
+
+			content, err := ioutil.ReadFile(backupPath)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.content, string(content))
+
+			c, err := Load(gowrapHome)
+			require.NoError(t, err)
+			assert.NoError(t, c.Validate())
+		})
+	}
+}
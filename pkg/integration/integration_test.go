@@ -21,11 +21,12 @@ import (
 var goVersionCommand = []string{"go", "version"}
 
 func Test_CLIs(t *testing.T) {
-	downloadsDir, err := ioutil.TempDir(os.TempDir(), "gowrap-integration-downloads-")
+	// archives are cached and reused between test cases
+	cacheDir, err := ioutil.TempDir(os.TempDir(), "gowrap-integration-cache-")
 	require.NoError(t, err)
-	defer os.RemoveAll(downloadsDir)
+	defer os.RemoveAll(cacheDir)
 
-	require.NoError(t, os.Setenv("GOWRAP_DOWNLOADS_DIR", downloadsDir))
+	require.NoError(t, os.Setenv("GOWRAP_CACHE_DIR", cacheDir))
 
 	testCases := map[string]struct {
 		init             func(testDir string) (string, error)
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
 	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
 	"golang.org/x/mod/modfile"
 )
@@ -13,9 +14,15 @@ import (
 const (
 	goModFile     = "go.mod"
 	goVersionFile = ".go-version"
+
+	toolchainDirective = "toolchain"
 )
 
-func findProjectRoot(directory string) (string, error) {
+// findProjectRoot returns the closest directory, starting from the given one,
+// containing either go.mod, .go-version or another version file defining a Go
+// version. Version files shared with other tools (e.g. a .tool-versions only
+// pinning nodejs) or malformed ones don't mark a project root.
+func findProjectRoot(directory string, versionFiles []string) (string, error) {
 	candidateGoModPath := filepath.Join(directory, goModFile)
 	if goModExists, err := fileExists(candidateGoModPath); err != nil {
 		return "", err
@@ -23,10 +30,9 @@ func findProjectRoot(directory string) (string, error) {
 		return directory, nil
 	}
 
-	candidateGoVersionPath := filepath.Join(directory, goVersionFile)
-	if goVersionExists, err := fileExists(candidateGoVersionPath); err != nil {
+	if isRoot, err := hasRootVersionFile(directory, versionFiles); err != nil {
 		return "", err
-	} else if goVersionExists {
+	} else if isRoot {
 		return directory, nil
 	}
 
@@ -35,7 +41,7 @@ func findProjectRoot(directory string) (string, error) {
 		return "", customerrors.NotFound()
 	}
 
-	return findProjectRoot(parent)
+	return findProjectRoot(parent, versionFiles)
 }
 
 func fileExists(path string) (bool, error) {
@@ -47,40 +53,79 @@ func fileExists(path string) (bool, error) {
 	return err == nil && !info.Mode().IsDir(), nil
 }
 
-func findGoVersion(projectRoot string) (string, error) {
-	version, err := findVersionInGoVersionFile(projectRoot)
+// findGoVersion returns the version defined for the project and the source it
+// was read from. Version files have preference over go.mod.
+func findGoVersion(projectRoot string, versionFiles []string) (string, string, error) {
+	version, source, err := findVersionInVersionFiles(projectRoot, versionFiles)
 	if err == nil {
-		return version, nil
+		return version, source, nil
 	} else if customerrors.IsNotFound(err) {
 		return findVersionInGoModFile(projectRoot)
 	}
 
-	return "", err
+	return "", "", err
+}
+
+// findVersionInGoModFile returns the version defined in go.mod, giving
+// preference to the toolchain directive over the go directive.
+func findVersionInGoModFile(projectRoot string) (string, string, error) {
+	goMod, err := parseGoModFile(projectRoot)
+	if err != nil {
+		return "", "", err
+	}
+
+	if toolchainVersion := findToolchainVersion(goMod.Syntax); len(toolchainVersion) > 0 {
+		return toolchainVersion, SourceToolchain, nil
+	}
+
+	if goMod.Go == nil {
+		return "", "", customerrors.NotFound()
+	}
+
+	return goMod.Go.Version, SourceGo, nil
 }
 
-func findVersionInGoVersionFile(projectRoot string) (string, error) {
-	goVersionPath := filepath.Join(projectRoot, goVersionFile)
-	content, err := readFile(goVersionPath)
+// findGoDirectiveVersion returns the version in the go directive of go.mod.
+func findGoDirectiveVersion(projectRoot string) (string, error) {
+	goMod, err := parseGoModFile(projectRoot)
 	if err != nil {
 		return "", err
+	} else if goMod.Go == nil {
+		return "", customerrors.NotFound()
 	}
 
-	return strings.TrimSpace(string(content)), nil
+	return goMod.Go.Version, nil
 }
 
-func findVersionInGoModFile(projectRoot string) (string, error) {
+func parseGoModFile(projectRoot string) (*modfile.File, error) {
 	goModPath := filepath.Join(projectRoot, goModFile)
 	content, err := readFile(goModPath)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	goMod, err := modfile.ParseLax(goModPath, content, nil)
-	if err != nil {
-		return "", err
+	return modfile.ParseLax(goModPath, content, nil)
+}
+
+// findToolchainVersion returns the version in the toolchain directive, if any.
+// Lax parsing ignores the toolchain directive, so it is read from the syntax
+// tree instead. Toolchains not naming a valid Go version (e.g. "default") are
+// ignored.
+func findToolchainVersion(syntax *modfile.FileSyntax) string {
+	for _, stmt := range syntax.Stmt {
+		line, ok := stmt.(*modfile.Line)
+		if !ok || len(line.Token) != 2 || line.Token[0] != toolchainDirective {
+			continue
+		}
+
+		version := strings.TrimPrefix(line.Token[1], goVersionPrefix)
+		version = strings.SplitN(version, "-", 2)[0]
+		if semver.IsValid(version) {
+			return version
+		}
 	}
 
-	return goMod.Go.Version, nil
+	return ""
 }
 
 func readFile(path string) ([]byte, error) {
@@ -0,0 +1,89 @@
+package project
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+func Test_findGoVersion(t *testing.T) {
+	testCases := map[string]struct {
+		files map[string]string
+
+		expectedVersion  string
+		expectedSource   string
+		expectedNotFound bool
+	}{
+		"GoDirective": {
+			files:           map[string]string{goModFile: "module foo\n\ngo 1.21\n"},
+			expectedVersion: "1.21",
+			expectedSource:  SourceGo,
+		},
+		"ToolchainDirective": {
+			files:           map[string]string{goModFile: "module foo\n\ngo 1.21\n\ntoolchain go1.21.6\n"},
+			expectedVersion: "1.21.6",
+			expectedSource:  SourceToolchain,
+		},
+		"ToolchainDirectiveWithSuffix": {
+			files:           map[string]string{goModFile: "module foo\n\ngo 1.21\n\ntoolchain go1.21.6-custom\n"},
+			expectedVersion: "1.21.6",
+			expectedSource:  SourceToolchain,
+		},
+		"DefaultToolchainDirective": {
+			files:           map[string]string{goModFile: "module foo\n\ngo 1.21\n\ntoolchain default\n"},
+			expectedVersion: "1.21",
+			expectedSource:  SourceGo,
+		},
+		"GoVersionFileHasPreferenceOverToolchain": {
+			files: map[string]string{
+				goModFile:     "module foo\n\ngo 1.21\n\ntoolchain go1.21.6\n",
+				goVersionFile: "1.21.5\n",
+			},
+			expectedVersion: "1.21.5",
+			expectedSource:  SourceGoVersionFile,
+		},
+		"NoGoDirective": {
+			files:            map[string]string{goModFile: "module foo\n"},
+			expectedNotFound: true,
+		},
+		"NoFiles": {
+			files:            map[string]string{},
+			expectedNotFound: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			projectRoot := createProject(t, testCase.files)
+
+			version, source, err := findGoVersion(projectRoot, DefaultVersionFiles())
+			if testCase.expectedNotFound {
+				assert.True(t, customerrors.IsNotFound(err), "unexpected error: %v", err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedVersion, version)
+			assert.Equal(t, testCase.expectedSource, source)
+		})
+	}
+}
+
+func createProject(t *testing.T, files map[string]string) string {
+	projectRoot, err := ioutil.TempDir(os.TempDir(), "test-project-")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(projectRoot) })
+
+	for name, content := range files {
+		path := filepath.Join(projectRoot, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	}
+
+	return projectRoot
+}
@@ -11,10 +11,32 @@ import (
 	"github.com/xabierlaiseca/gowrap/pkg/versions"
 )
 
+// Sources from where the defined version can be read. Versions read from
+// version files use the name of the file as source.
+const (
+	SourceGoVersionFile = goVersionFile
+	SourceToolchain     = "toolchain"
+	SourceGo            = "go"
+	SourceDirectory     = "directory"
+	SourceDefault       = "default"
+)
+
 // Version contains details about the Go version to be used.
 type Version struct {
 	Defined   string
 	Installed string
+	// Source is where the defined version was read from, empty if no version
+	// was defined.
+	Source string
+	// SourcePath is the path of the file the defined version was read from,
+	// empty if not read from a file.
+	SourcePath string
+	// ProjectRoot is the root directory of the project, empty if not in a
+	// project.
+	ProjectRoot string
+	// WorkspaceRoot is the root directory of the Go workspace, empty if not in
+	// a workspace.
+	WorkspaceRoot string
 }
 
 func (pv *Version) IsAvailable() bool {
@@ -36,40 +58,102 @@ func DetectVersion(gowrapHome, path string) (*Version, error) {
 		return nil, customerrors.Errorf("provided path is not directory: %s", path)
 	}
 
-	projectRoot, err := findProjectRoot(p)
+	versionFiles, err := loadVersionFiles(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := findDefinedVersion(p, versionFiles)
 	if customerrors.IsNotFound(err) {
-		return detectVersionOutsideProject(gowrapHome)
+		return detectVersionOutsideProject(gowrapHome, p)
 	} else if err != nil {
 		return nil, err
 	}
 
-	definedVersion, err := findGoVersion(projectRoot)
-	if err != nil {
+	version.Installed, err = versions.FindLatestInstalledForPrefix(gowrapHome, version.Defined)
+	if err != nil && !customerrors.IsNotFound(err) {
 		return nil, err
 	}
 
-	installedVersion, err := versions.FindLatestInstalledForPrefix(gowrapHome, definedVersion)
-	if err != nil && !customerrors.IsNotFound(err) {
+	return version, nil
+}
+
+// findDefinedVersion returns the version defined by the workspace or the
+// project containing the given directory. Workspace definitions have
+// preference over the ones of the project.
+func findDefinedVersion(directory string, versionFiles []string) (*Version, error) {
+	version := &Version{}
+
+	projectRoot, err := findProjectRoot(directory, versionFiles)
+	if err == nil {
+		version.ProjectRoot = projectRoot
+	} else if !customerrors.IsNotFound(err) {
 		return nil, err
 	}
 
-	return &Version{
-		Defined:   definedVersion,
-		Installed: installedVersion,
-	}, nil
+	goWorkPath, err := findWorkspaceFile(directory)
+	switch {
+	case err == nil:
+		version.WorkspaceRoot = filepath.Dir(goWorkPath)
+		version.Defined, version.Source, err = findWorkspaceGoVersion(goWorkPath, versionFiles)
+		version.SourcePath = sourcePathFor(version.WorkspaceRoot, goWorkPath, version.Source)
+	case customerrors.IsNotFound(err) && len(projectRoot) > 0:
+		version.Defined, version.Source, err = findGoVersion(projectRoot, versionFiles)
+		version.SourcePath = sourcePathFor(projectRoot, filepath.Join(projectRoot, goModFile), version.Source)
+	}
+
+	return version, err
+}
+
+// sourcePathFor returns the path of the file the version was read from: the
+// version file in root, or the given module or workspace file for versions
+// read from directives.
+func sourcePathFor(root, directivesPath, source string) string {
+	switch source {
+	case "":
+		return ""
+	case SourceGo, SourceToolchain:
+		return directivesPath
+	}
+
+	return filepath.Join(root, source)
 }
 
-func detectVersionOutsideProject(gowrapHome string) (*Version, error) {
+// detectVersionOutsideProject detects the version to use in a directory
+// without a version defined by a project: the version configured for the
+// directory, or otherwise the default version.
+func detectVersionOutsideProject(gowrapHome, directory string) (*Version, error) {
 	configuration, err := config.Load(gowrapHome)
 	if err != nil {
 		return nil, err
 	}
 
+	absDirectory, err := filepath.Abs(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	// directory versions are configured for resolved paths
+	absDirectory, err = filepath.EvalSymlinks(absDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	if directoryVersion, _, err := configuration.FindDirectoryVersion(absDirectory); err == nil {
+		installedVersionToUse, err := versions.FindLatestInstalledForPrefix(gowrapHome, directoryVersion)
+		return &Version{
+			Defined:   directoryVersion,
+			Installed: installedVersionToUse,
+			Source:    SourceDirectory,
+		}, err
+	}
+
 	definedVersion := strings.TrimSpace(configuration.DefaultVersion)
 
-	var installedVersionToUse string
+	var installedVersionToUse, source string
 	if semver.IsValid(definedVersion) {
 		installedVersionToUse, err = versions.FindLatestInstalledForPrefix(gowrapHome, definedVersion)
+		source = SourceDefault
 	} else {
 		installedVersionToUse, err = versions.FindLatestInstalled(gowrapHome)
 	}
@@ -77,5 +161,6 @@ func detectVersionOutsideProject(gowrapHome string) (*Version, error) {
 	return &Version{
 		Defined:   definedVersion,
 		Installed: installedVersionToUse,
+		Source:    source,
 	}, err
 }
@@ -0,0 +1,84 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/config"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+func Test_DetectVersion_DirectoryVersions(t *testing.T) {
+	root := createProject(t, map[string]string{
+		"legacy/src/app/main.go":   "package main\n",
+		"legacy/vendor/lib/lib.go": "package lib\n",
+		"module/" + goModFile:      "module m\n\ngo 1.22\n",
+		"scratch/notes.txt":        "",
+	})
+	root, err := filepath.EvalSymlinks(root)
+	require.NoError(t, err)
+	require.NoError(t, os.Symlink(filepath.Join(root, "legacy", "src"), filepath.Join(root, "linked")))
+
+	testCases := map[string]struct {
+		directory       string
+		expectedVersion string
+		expectedSource  string
+	}{
+		"ConfiguredDirectory": {
+			directory:       "legacy",
+			expectedVersion: "1.16",
+			expectedSource:  SourceDirectory,
+		},
+		"SubdirectoryOfConfiguredDirectory": {
+			directory:       "legacy/src/app",
+			expectedVersion: "1.16",
+			expectedSource:  SourceDirectory,
+		},
+		"ClosestConfiguredDirectory": {
+			directory:       "legacy/vendor/lib",
+			expectedVersion: "1.15.15",
+			expectedSource:  SourceDirectory,
+		},
+		"SymlinkToConfiguredDirectory": {
+			directory:       "linked/app",
+			expectedVersion: "1.16",
+			expectedSource:  SourceDirectory,
+		},
+		"ProjectHasPreference": {
+			directory:       "module",
+			expectedVersion: "1.22",
+			expectedSource:  SourceGo,
+		},
+		"NotConfiguredDirectory": {
+			directory:       "scratch",
+			expectedVersion: "1.21",
+			expectedSource:  SourceDefault,
+		},
+	}
+
+	gowrapHome := createProject(t, nil)
+	c, err := config.Load(gowrapHome)
+	require.NoError(t, err)
+	c.DefaultVersion = "1.21"
+	c.SetDirectoryVersion(filepath.Join(root, "legacy"), "1.16")
+	c.SetDirectoryVersion(filepath.Join(root, "legacy", "vendor"), "1.15.15")
+	c.SetDirectoryVersion(filepath.Join(root, "module"), "1.20")
+	require.NoError(t, c.Save())
+	require.NoError(t, os.MkdirAll(filepath.Join(gowrapHome, "versions"), 0755))
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			version, err := DetectVersion(gowrapHome, filepath.Join(root, testCase.directory))
+			if err != nil {
+				// no versions are installed
+				require.True(t, customerrors.IsNotFound(err), err)
+			}
+
+			assert.Equal(t, testCase.expectedVersion, version.Defined)
+			assert.Equal(t, testCase.expectedSource, version.Source)
+		})
+	}
+}
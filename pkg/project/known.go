@@ -0,0 +1,66 @@
+package project
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const knownProjectsFile = "projects.json"
+
+// KnownProjects returns the roots of the projects gowrap knows about, such as
+// the ones where a version was pinned, that still exist.
+func KnownProjects(gowrapHome string) ([]string, error) {
+	roots, err := readKnownProjects(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make([]string, 0, len(roots))
+	for _, root := range roots {
+		if info, err := os.Stat(root); err == nil && info.IsDir() {
+			existing = append(existing, root)
+		}
+	}
+
+	return existing, nil
+}
+
+// recordKnownProject adds the given project root to the known projects.
+func recordKnownProject(gowrapHome, root string) error {
+	roots, err := readKnownProjects(gowrapHome)
+	if err != nil {
+		return err
+	}
+
+	for _, knownRoot := range roots {
+		if knownRoot == root {
+			return nil
+		}
+	}
+
+	roots = append(roots, root)
+	sort.Strings(roots)
+
+	content, err := json.MarshalIndent(roots, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(gowrapHome, knownProjectsFile), content, 0600)
+}
+
+func readKnownProjects(gowrapHome string) ([]string, error) {
+	content, err := ioutil.ReadFile(filepath.Join(gowrapHome, knownProjectsFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var roots []string
+	err = json.Unmarshal(content, &roots)
+	return roots, err
+}
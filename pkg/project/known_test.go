@@ -0,0 +1,25 @@
+package project
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_KnownProjects(t *testing.T) {
+	gowrapHome := createProject(t, nil)
+	project1 := createProject(t, map[string]string{"go.mod": "module p1\n\ngo 1.21\n"})
+	project2 := createProject(t, map[string]string{"go.mod": "module p2\n\ngo 1.20\n"})
+	removedProject := createProject(t, map[string]string{"go.mod": "module p3\n\ngo 1.20\n"})
+
+	for _, projectRoot := range []string{project1, project2, project1, removedProject} {
+		require.NoError(t, recordKnownProject(gowrapHome, projectRoot))
+	}
+	require.NoError(t, os.RemoveAll(removedProject))
+
+	knownProjects, err := KnownProjects(gowrapHome)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{project1, project2}, knownProjects)
+}
@@ -9,8 +9,13 @@ import (
 	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
 )
 
-func PinVersion(path string, version string) error {
-	projectRoot, err := findProjectRoot(path)
+func PinVersion(gowrapHome, path string, version string) error {
+	versionFiles, err := loadVersionFiles(gowrapHome)
+	if err != nil {
+		return err
+	}
+
+	projectRoot, err := findPinRoot(path, versionFiles)
 	if customerrors.IsNotFound(err) {
 		logrus.Warning("Cannot pin version, currently not in a Go project")
 		return nil
@@ -18,12 +23,18 @@ func PinVersion(path string, version string) error {
 		return err
 	}
 
-	if goModVersion, err := findVersionInGoModFile(projectRoot); err != nil && !customerrors.IsNotFound(err) {
+	if goModVersion, err := findGoDirectiveVersion(projectRoot); err != nil && !customerrors.IsNotFound(err) {
 		return err
-	} else if err == nil && !semver.HasPrefix(version, goModVersion) {
+	} else if err == nil && !semver.IsDevel(version) && !semver.HasPrefix(version, goModVersion) {
 		logrus.Warningf("Pinned version (%s) is not compatible with version in go.mod (%s)", version, goModVersion)
 	}
 
+	warnIfPinIsShadowed(projectRoot, versionFiles)
+
+	if err := recordKnownProject(gowrapHome, projectRoot); err != nil {
+		logrus.Warningf("Failed to record known project: %v", err)
+	}
+
 	goVersionPath := filepath.Join(projectRoot, goVersionFile)
 	file, err := os.OpenFile(goVersionPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
@@ -35,8 +46,13 @@ func PinVersion(path string, version string) error {
 	return err
 }
 
-func UnpinVersion(path string) error {
-	projectRoot, err := findProjectRoot(path)
+func UnpinVersion(gowrapHome, path string) error {
+	versionFiles, err := loadVersionFiles(gowrapHome)
+	if err != nil {
+		return err
+	}
+
+	projectRoot, err := findPinRoot(path, versionFiles)
 	if customerrors.IsNotFound(err) {
 		logrus.Warning("Cannot unpin version, currently not in a Go project")
 		return nil
@@ -55,3 +71,20 @@ func UnpinVersion(path string) error {
 
 	return os.RemoveAll(goVersionPath)
 }
+
+// warnIfPinIsShadowed warns if a version file with preference over
+// .go-version defines a version, as the pinned version would not be used.
+func warnIfPinIsShadowed(projectRoot string, versionFiles []string) {
+	for _, name := range versionFiles {
+		if name == goVersionFile {
+			return
+		}
+
+		if version, err := findVersionInVersionFile(projectRoot, name); err == nil {
+			logrus.Warningf("Version defined in %s (%s) has preference over pinned version", name, version)
+			return
+		}
+	}
+
+	logrus.Warningf("%s is not configured as version file, pinned version will not be used", goVersionFile)
+}
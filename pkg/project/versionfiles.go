@@ -0,0 +1,187 @@
+package project
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"strings"
+
+	"github.com/xabierlaiseca/gowrap/pkg/config"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+const (
+	toolVersionsFile = ".tool-versions"
+	gvmrcFile        = ".gvmrc"
+
+	goVersionPrefix = "go"
+)
+
+// versionFileReader extracts the Go version from the content of a version
+// file. It returns a not found error if the file doesn't define a Go version.
+type versionFileReader func(content []byte) (string, error)
+
+// DefaultVersionFiles returns the supported version files, sorted by their
+// default precedence.
+func DefaultVersionFiles() []string {
+	return []string{goVersionFile, toolVersionsFile, gvmrcFile}
+}
+
+// IsSupportedVersionFile returns true if there is a reader for the given
+// version file.
+func IsSupportedVersionFile(name string) bool {
+	_, found := getVersionFileReader(name)
+	return found
+}
+
+func getVersionFileReader(name string) (versionFileReader, bool) {
+	switch name {
+	case goVersionFile:
+		return readGoVersionFile, true
+	case toolVersionsFile:
+		return readToolVersionsFile, true
+	case gvmrcFile:
+		return readGvmrcFile, true
+	}
+
+	return nil, false
+}
+
+// versionFilesFor returns the version files to use, sorted by precedence.
+func versionFilesFor(c *config.Configuration) []string {
+	if len(c.VersionFiles) > 0 {
+		return c.VersionFiles
+	}
+
+	return DefaultVersionFiles()
+}
+
+func loadVersionFiles(gowrapHome string) ([]string, error) {
+	c, err := config.Load(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	return versionFilesFor(c), nil
+}
+
+// findVersionInVersionFiles returns the version defined by the first version
+// file in the directory defining one, and the name of that file. Version files
+// with lower precedence are not read.
+func findVersionInVersionFiles(directory string, versionFiles []string) (string, string, error) {
+	for _, name := range versionFiles {
+		version, err := findVersionInVersionFile(directory, name)
+		if err == nil {
+			return version, name, nil
+		} else if !customerrors.IsNotFound(err) {
+			return "", "", err
+		}
+	}
+
+	return "", "", customerrors.NotFound()
+}
+
+// hasRootVersionFile returns true if the directory contains a version file
+// marking a project root: .go-version, even if empty, or any other version
+// file defining a valid Go version.
+func hasRootVersionFile(directory string, versionFiles []string) (bool, error) {
+	for _, name := range versionFiles {
+		if name == goVersionFile {
+			if exists, err := fileExists(filepath.Join(directory, name)); err != nil || exists {
+				return exists, err
+			}
+			continue
+		}
+
+		if _, err := findVersionInVersionFile(directory, name); err == nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func findVersionInVersionFile(directory, name string) (string, error) {
+	reader, found := getVersionFileReader(name)
+	if !found {
+		return "", customerrors.Errorf("unsupported version file: %s", name)
+	}
+
+	path := filepath.Join(directory, name)
+	if exists, err := fileExists(path); err != nil {
+		return "", err
+	} else if !exists {
+		return "", customerrors.NotFound()
+	}
+
+	content, err := readFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	version, err := reader(content)
+	if err == nil && !semver.IsValid(version) {
+		return "", customerrors.Errorf("invalid go version in %s: %s", path, version)
+	}
+
+	return version, err
+}
+
+// readGoVersionFile reads .go-version files, as written by gowrap or goenv,
+// optionally using the "go" prefix.
+func readGoVersionFile(content []byte) (string, error) {
+	version := strings.TrimSpace(string(content))
+	if len(version) == 0 {
+		return "", customerrors.NotFound()
+	}
+
+	return strings.TrimPrefix(version, goVersionPrefix), nil
+}
+
+// readToolVersionsFile reads asdf .tool-versions files, where each line
+// contains a tool followed by its versions in order of preference.
+func readToolVersionsFile(content []byte) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(strings.SplitN(scanner.Text(), "#", 2)[0])
+		if len(fields) < 2 || (fields[0] != "golang" && fields[0] != "go") {
+			continue
+		}
+
+		for _, version := range fields[1:] {
+			version = strings.TrimPrefix(version, goVersionPrefix)
+			if semver.IsValid(version) {
+				return version, nil
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", customerrors.NotFound()
+}
+
+// readGvmrcFile reads .gvmrc files, containing either the go version (e.g.
+// "go1.21.5") or the gvm command to select it (e.g. "gvm use go1.21.5").
+func readGvmrcFile(content []byte) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(strings.SplitN(scanner.Text(), "#", 2)[0])
+		if len(fields) >= 3 && fields[0] == "gvm" && fields[1] == "use" {
+			fields = fields[2:]
+		}
+
+		if len(fields) > 0 && strings.HasPrefix(fields[0], goVersionPrefix) {
+			return strings.TrimPrefix(fields[0], goVersionPrefix), nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", customerrors.NotFound()
+}
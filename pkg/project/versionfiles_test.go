@@ -0,0 +1,187 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+func Test_versionFileReaders(t *testing.T) {
+	testCases := map[string]struct {
+		reader  versionFileReader
+		content string
+
+		expected         string
+		expectedNotFound bool
+	}{
+		"GoVersionFile": {
+			reader:   readGoVersionFile,
+			content:  "1.21.5\n",
+			expected: "1.21.5",
+		},
+		"GoVersionFileWithPrefix": {
+			reader:   readGoVersionFile,
+			content:  "go1.21.5\n",
+			expected: "1.21.5",
+		},
+		"EmptyGoVersionFile": {
+			reader:           readGoVersionFile,
+			content:          "\n",
+			expectedNotFound: true,
+		},
+		"ToolVersionsFile": {
+			reader:   readToolVersionsFile,
+			content:  "nodejs 20.10.0\ngolang 1.21.5\n",
+			expected: "1.21.5",
+		},
+		"ToolVersionsFileWithFallbackVersions": {
+			reader:   readToolVersionsFile,
+			content:  "golang system 1.21.5 1.20.12 # comment\n",
+			expected: "1.21.5",
+		},
+		"ToolVersionsFileWithGoTool": {
+			reader:   readToolVersionsFile,
+			content:  "go 1.22.0\n",
+			expected: "1.22.0",
+		},
+		"ToolVersionsFileWithCommentedGolang": {
+			reader:           readToolVersionsFile,
+			content:          "# golang 1.21.5\nnodejs 20.10.0\n",
+			expectedNotFound: true,
+		},
+		"GvmrcFile": {
+			reader:   readGvmrcFile,
+			content:  "go1.21.5\n",
+			expected: "1.21.5",
+		},
+		"GvmrcFileWithCommand": {
+			reader:   readGvmrcFile,
+			content:  "# select go version\ngvm use go1.21.5 --default\n",
+			expected: "1.21.5",
+		},
+		"GvmrcFileWithoutVersion": {
+			reader:           readGvmrcFile,
+			content:          "gvm pkgset use global\n",
+			expectedNotFound: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			actual, err := testCase.reader([]byte(testCase.content))
+			if testCase.expectedNotFound {
+				assert.True(t, customerrors.IsNotFound(err), "unexpected error: %v", err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func Test_findGoVersion_VersionFilesPrecedence(t *testing.T) {
+	projectRoot := createProject(t, map[string]string{
+		goModFile:        "module foo\n\ngo 1.21\n",
+		goVersionFile:    "1.21.5",
+		toolVersionsFile: "golang 1.21.6\n",
+	})
+
+	version, source, err := findGoVersion(projectRoot, []string{toolVersionsFile, goVersionFile})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.21.6", version)
+	assert.Equal(t, toolVersionsFile, source)
+
+	version, source, err = findGoVersion(projectRoot, []string{goVersionFile, toolVersionsFile})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.21.5", version)
+	assert.Equal(t, goVersionFile, source)
+}
+
+func Test_findGoVersion_StopsAtFirstVersionFileDefiningVersion(t *testing.T) {
+	projectRoot := createProject(t, map[string]string{
+		goModFile:        "module foo\n\ngo 1.21\n",
+		goVersionFile:    "\n",
+		toolVersionsFile: "golang 1.21.6\n",
+		gvmrcFile:        "go1.x\n",
+	})
+
+	version, source, err := findGoVersion(projectRoot, DefaultVersionFiles())
+	assert.NoError(t, err)
+	assert.Equal(t, "1.21.6", version)
+	assert.Equal(t, toolVersionsFile, source)
+}
+
+func Test_findProjectRoot(t *testing.T) {
+	testCases := map[string]struct {
+		files     map[string]string
+		directory string
+		expected  string
+	}{
+		"GoModFile": {
+			files:     map[string]string{"project/" + goModFile: "module foo\n"},
+			directory: "project/pkg",
+			expected:  "project",
+		},
+		"EmptyGoVersionFile": {
+			files:     map[string]string{"project/" + goVersionFile: ""},
+			directory: "project/pkg",
+			expected:  "project",
+		},
+		"VersionFileWithGoVersion": {
+			files: map[string]string{
+				goVersionFile:                 "1.21.6\n",
+				"project/" + toolVersionsFile: "nodejs 20.10.0\ngolang 1.21.5\n",
+			},
+			directory: "project/pkg",
+			expected:  "project",
+		},
+		"VersionFileWithoutGoVersion": {
+			files: map[string]string{
+				goModFile:                     "module foo\n",
+				"project/" + toolVersionsFile: "nodejs 20.10.0\n",
+			},
+			directory: "project",
+			expected:  "",
+		},
+		"MalformedVersionFile": {
+			files: map[string]string{
+				goModFile:                     "module foo\n",
+				"project/" + toolVersionsFile: "golang 1.x\n",
+			},
+			directory: "project",
+			expected:  "",
+		},
+		"MalformedParentVersionFile": {
+			files: map[string]string{
+				gvmrcFile:              "go1.x\n",
+				"project/" + goModFile: "module foo\n",
+			},
+			directory: "project",
+			expected:  "project",
+		},
+		"UnconfiguredVersionFile": {
+			files: map[string]string{
+				goVersionFile:          "1.21.6\n",
+				"project/" + gvmrcFile: "go1.21.5\n",
+			},
+			directory: "project",
+			expected:  "",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			root := createProject(t, testCase.files)
+			require.NoError(t, os.MkdirAll(filepath.Join(root, testCase.directory), 0755))
+
+			actual, err := findProjectRoot(filepath.Join(root, testCase.directory), []string{goVersionFile, toolVersionsFile})
+			assert.NoError(t, err)
+			assert.Equal(t, filepath.Join(root, testCase.expected), actual)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	goWorkFile   = "go.work"
+	goWorkEnvVar = "GOWORK"
+	goWorkOff    = "off"
+)
+
+// findWorkspaceFile returns the path of the go.work file for the workspace
+// containing the given directory, following the same rules as the go command:
+// GOWORK has preference over looking for go.work in the directory and its
+// parents, and workspaces are disabled if GOWORK is set to "off".
+func findWorkspaceFile(directory string) (string, error) {
+	if goWork := os.Getenv(goWorkEnvVar); goWork == goWorkOff {
+		return "", customerrors.NotFound()
+	} else if len(goWork) > 0 && !filepath.IsAbs(goWork) {
+		return "", customerrors.Errorf("%s must be an absolute path: %s", goWorkEnvVar, goWork)
+	} else if len(goWork) > 0 {
+		return goWork, nil
+	}
+
+	candidateGoWorkPath := filepath.Join(directory, goWorkFile)
+	if goWorkExists, err := fileExists(candidateGoWorkPath); err != nil {
+		return "", err
+	} else if goWorkExists {
+		return candidateGoWorkPath, nil
+	}
+
+	parent := filepath.Dir(directory)
+	if parent == directory {
+		return "", customerrors.NotFound()
+	}
+
+	return findWorkspaceFile(parent)
+}
+
+// findWorkspaceGoVersion returns the version defined for the workspace and the
+// source it was read from. Version files in the workspace root have
+// preference over go.work directives, and if go.work doesn't define a
+// version, the highest go directive of the used modules is returned.
+func findWorkspaceGoVersion(goWorkPath string, versionFiles []string) (string, string, error) {
+	version, source, err := findVersionInVersionFiles(filepath.Dir(goWorkPath), versionFiles)
+	if err == nil {
+		return version, source, nil
+	} else if !customerrors.IsNotFound(err) {
+		return "", "", err
+	}
+
+	content, err := readFile(goWorkPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	goWork, err := modfile.ParseWork(goWorkPath, content, nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	if toolchainVersion := findToolchainVersion(goWork.Syntax); len(toolchainVersion) > 0 {
+		return toolchainVersion, SourceToolchain, nil
+	} else if goWork.Go != nil {
+		return goWork.Go.Version, SourceGo, nil
+	}
+
+	version, err = findHighestGoDirectiveVersion(goWorkPath, goWork.Use)
+	return version, SourceGo, err
+}
+
+func findHighestGoDirectiveVersion(goWorkPath string, uses []*modfile.Use) (string, error) {
+	var moduleVersions []string
+	for _, use := range uses {
+		moduleRoot := use.Path
+		if !filepath.IsAbs(moduleRoot) {
+			moduleRoot = filepath.Join(filepath.Dir(goWorkPath), moduleRoot)
+		}
+
+		moduleVersion, err := findGoDirectiveVersion(moduleRoot)
+		if customerrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+
+		moduleVersions = append(moduleVersions, moduleVersion)
+	}
+
+	if len(moduleVersions) == 0 {
+		return "", customerrors.NotFound()
+	}
+
+	return semver.Latest(moduleVersions)
+}
+
+// findPinRoot returns the directory where the version for the given path is
+// pinned: the workspace root if in a workspace, otherwise the project root.
+func findPinRoot(path string, versionFiles []string) (string, error) {
+	goWorkPath, err := findWorkspaceFile(path)
+	if err == nil {
+		return filepath.Dir(goWorkPath), nil
+	} else if !customerrors.IsNotFound(err) {
+		return "", err
+	}
+
+	return findProjectRoot(path, versionFiles)
+}
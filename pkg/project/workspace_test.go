@@ -0,0 +1,82 @@
+package project
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_findDefinedVersion_Workspace(t *testing.T) {
+	testCases := map[string]struct {
+		files map[string]string
+
+		expectedVersion string
+		expectedSource  string
+	}{
+		"GoWorkToolchainDirective": {
+			files: map[string]string{
+				goWorkFile:           "go 1.21\n\ntoolchain go1.21.6\n\nuse ./a\n",
+				"a/" + goModFile:     "module a\n\ngo 1.22\n",
+				"a/" + goVersionFile: "1.22.1",
+			},
+			expectedVersion: "1.21.6",
+			expectedSource:  SourceToolchain,
+		},
+		"GoWorkGoDirective": {
+			files: map[string]string{
+				goWorkFile:       "go 1.21\n\nuse ./a\n",
+				"a/" + goModFile: "module a\n\ngo 1.22\n",
+			},
+			expectedVersion: "1.21",
+			expectedSource:  SourceGo,
+		},
+		"GoVersionFileInWorkspaceRoot": {
+			files: map[string]string{
+				goWorkFile:       "go 1.21\n\nuse ./a\n",
+				goVersionFile:    "1.21.3\n",
+				"a/" + goModFile: "module a\n\ngo 1.22\n",
+			},
+			expectedVersion: "1.21.3",
+			expectedSource:  SourceGoVersionFile,
+		},
+		"HighestGoDirectiveInUsedModules": {
+			files: map[string]string{
+				goWorkFile:       "use (\n\t./a\n\t./b\n\t./c\n)\n",
+				"a/" + goModFile: "module a\n\ngo 1.20\n",
+				"b/" + goModFile: "module b\n\ngo 1.21\n",
+				"c/" + goModFile: "module c\n",
+			},
+			expectedVersion: "1.21",
+			expectedSource:  SourceGo,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			t.Setenv(goWorkEnvVar, "")
+			workspaceRoot := createProject(t, testCase.files)
+
+			actual, err := findDefinedVersion(filepath.Join(workspaceRoot, "a"), DefaultVersionFiles())
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedVersion, actual.Defined)
+			assert.Equal(t, testCase.expectedSource, actual.Source)
+			assert.Equal(t, workspaceRoot, actual.WorkspaceRoot)
+			assert.Equal(t, filepath.Join(workspaceRoot, "a"), actual.ProjectRoot)
+		})
+	}
+}
+
+func Test_findDefinedVersion_WorkspaceDisabled(t *testing.T) {
+	t.Setenv(goWorkEnvVar, goWorkOff)
+	workspaceRoot := createProject(t, map[string]string{
+		goWorkFile:       "go 1.21\n\nuse ./a\n",
+		"a/" + goModFile: "module a\n\ngo 1.22\n",
+	})
+
+	actual, err := findDefinedVersion(filepath.Join(workspaceRoot, "a"), DefaultVersionFiles())
+	require.NoError(t, err)
+	assert.Equal(t, "1.22", actual.Defined)
+	assert.Empty(t, actual.WorkspaceRoot)
+}
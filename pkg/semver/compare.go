@@ -33,7 +33,26 @@ func Latest(versions []string) (string, error) {
 	return latest, nil
 }
 
+// HasPrefix returns true if the given version belongs to the line identified
+// by prefix. A pre-release prefix only matches that same pre-release, while a
+// pre-release version still belongs to its major and minor line. Development
+// versions only match themselves.
 func HasPrefix(version, prefix string) bool {
+	if IsDevel(version) || IsDevel(prefix) {
+		return version == prefix
+	}
+
+	release, kind, number := splitPreRelease(version)
+	prefixRelease, prefixKind, prefixNumber := splitPreRelease(prefix)
+
+	if len(prefixKind) > 0 {
+		return kind == prefixKind && number == prefixNumber && isSameRelease(release, prefixRelease)
+	}
+
+	return hasReleasePrefix(release, prefixRelease)
+}
+
+func hasReleasePrefix(version, prefix string) bool {
 	switch {
 	case len(prefix) == 0:
 		return true
@@ -48,10 +67,53 @@ func HasPrefix(version, prefix string) bool {
 		return false
 	}
 
-	return HasPrefix(restVersion, restPrefix)
+	return hasReleasePrefix(restVersion, restPrefix)
 }
 
+// IsLessThan returns true if semver1 is older than semver2. Pre-releases are
+// older than any release of the major and minor line they belong to, e.g.
+// 1.20beta1 < 1.20rc1 < 1.20.0 < 1.20.1 and 1.22rc2 < 1.22.0.
+// Development versions are newer than any release, and tip is the newest one.
 func IsLessThan(semver1, semver2 string) bool {
+	if devel1, devel2 := IsDevel(semver1), IsDevel(semver2); devel1 || devel2 {
+		return isDevelLessThan(semver1, semver2, devel1, devel2)
+	}
+
+	release1, kind1, number1 := splitPreRelease(semver1)
+	release2, kind2, number2 := splitPreRelease(semver2)
+
+	switch {
+	case !isSameRelease(release1, release2):
+		return isReleaseLessThan(release1, release2)
+	case len(kind1) == 0 || len(kind2) == 0:
+		// a pre-release is older than the release of its line
+		return len(kind1) > 0 && len(kind2) == 0
+	case kind1 != kind2:
+		// "beta" and "rc" happen to be lexicographically sorted
+		return kind1 < kind2
+	default:
+		return number1 < number2
+	}
+}
+
+func isDevelLessThan(semver1, semver2 string, devel1, devel2 bool) bool {
+	switch {
+	case !devel1 || !devel2:
+		return devel2
+	case semver1 == Tip:
+		return false
+	case semver2 == Tip:
+		return true
+	default:
+		return semver1 < semver2
+	}
+}
+
+func isSameRelease(release1, release2 string) bool {
+	return !isReleaseLessThan(release1, release2) && !isReleaseLessThan(release2, release1)
+}
+
+func isReleaseLessThan(semver1, semver2 string) bool {
 	firstSegment1, rest1 := splitFirstSegment(semver1)
 	firstSegment2, rest2 := splitFirstSegment(semver2)
 
@@ -67,7 +129,7 @@ func IsLessThan(semver1, semver2 string) bool {
 	case len(rest2) == 0:
 		return false
 	default:
-		return IsLessThan(rest1, rest2)
+		return isReleaseLessThan(rest1, rest2)
 	}
 }
 
@@ -94,6 +94,66 @@ func Test_IsLessThan(t *testing.T) {
 			semver2:  "1.1.10",
 			expected: true,
 		},
+		"PreReleaseLessThanFirstPatch": {
+			semver1:  "1.22rc1",
+			semver2:  "1.22.0",
+			expected: true,
+		},
+		"FirstPatchGreaterThanPreRelease": {
+			semver1:  "1.22.0",
+			semver2:  "1.22rc1",
+			expected: false,
+		},
+		"PreReleaseGreaterThanPreviousMinor": {
+			semver1:  "1.22rc1",
+			semver2:  "1.21.7",
+			expected: false,
+		},
+		"MajorAndMinorReleaseGreaterThanPreRelease": {
+			semver1:  "1.20",
+			semver2:  "1.20rc1",
+			expected: false,
+		},
+		"PreReleaseLessThanMajorAndMinorRelease": {
+			semver1:  "1.20rc1",
+			semver2:  "1.20",
+			expected: true,
+		},
+		"BetaLessThanReleaseCandidate": {
+			semver1:  "1.22beta2",
+			semver2:  "1.22rc1",
+			expected: true,
+		},
+		"ComparesPreReleaseNumberAsNumber": {
+			semver1:  "1.22rc2",
+			semver2:  "1.22rc10",
+			expected: true,
+		},
+		"SamePreRelease": {
+			semver1:  "1.22rc1",
+			semver2:  "1.22rc1",
+			expected: false,
+		},
+		"ReleaseLessThanDevel": {
+			semver1:  "1.99.9",
+			semver2:  "devel-8e4a6a4c1b2d",
+			expected: true,
+		},
+		"DevelGreaterThanRelease": {
+			semver1:  "devel-8e4a6a4c1b2d",
+			semver2:  "1.99.9",
+			expected: false,
+		},
+		"DevelLessThanTip": {
+			semver1:  "devel-8e4a6a4c1b2d",
+			semver2:  "tip",
+			expected: true,
+		},
+		"TipGreaterThanDevel": {
+			semver1:  "tip",
+			semver2:  "devel-8e4a6a4c1b2d",
+			expected: false,
+		},
 	}
 
 	for testName, testCase := range testCases {
@@ -105,8 +165,8 @@ func Test_IsLessThan(t *testing.T) {
 }
 
 func Test_SliceStableComparatorFor_ValidVersions(t *testing.T) {
-	semvers := []string{"2", "1.20.1", "1.3", "1.20.4", "1.19.1"}
-	expected := []string{"1.3", "1.19.1", "1.20.1", "1.20.4", "2"}
+	semvers := []string{"2", "1.20.1", "1.3", "1.20rc1", "1.20", "1.20.4", "1.19.1", "1.20beta1", "1.22.0", "1.22rc1"}
+	expected := []string{"1.3", "1.19.1", "1.20beta1", "1.20rc1", "1.20", "1.20.1", "1.20.4", "1.22rc1", "1.22.0", "2"}
 
 	comparator, err := SliceStableComparatorFor(semvers)
 	require.NoError(t, err)
@@ -144,6 +204,10 @@ func Test_Latest(t *testing.T) {
 			input:    []string{"1.3.2", "1.14.2", "1.13.2", "1.14.1"},
 			expected: "1.14.2",
 		},
+		"PreReleaseIsLatest": {
+			input:    []string{"1.14.2", "1.15rc1", "1.15beta1"},
+			expected: "1.15rc1",
+		},
 	}
 
 	for testName, testCase := range testCases {
@@ -211,12 +275,51 @@ func Test_HasPrefix(t *testing.T) {
 			prefix:   "1.",
 			expected: true,
 		},
-
 		"LongerPrefixThanVersion": {
 			version:  "1.14",
 			prefix:   "1.14.1",
 			expected: false,
 		},
+		"PreReleaseWithMajorAndMinorPrefix": {
+			version:  "1.22rc1",
+			prefix:   "1.22",
+			expected: true,
+		},
+		"PreReleaseWithPatchPrefix": {
+			version:  "1.22rc1",
+			prefix:   "1.22.0",
+			expected: false,
+		},
+		"PreReleaseWithSamePreReleasePrefix": {
+			version:  "1.22rc1",
+			prefix:   "1.22rc1",
+			expected: true,
+		},
+		"PreReleaseWithOtherPreReleasePrefix": {
+			version:  "1.22rc2",
+			prefix:   "1.22rc1",
+			expected: false,
+		},
+		"ReleaseWithPreReleasePrefix": {
+			version:  "1.22.0",
+			prefix:   "1.22rc1",
+			expected: false,
+		},
+		"DevelWithEmptyPrefix": {
+			version:  "tip",
+			prefix:   "",
+			expected: false,
+		},
+		"DevelWithSamePrefix": {
+			version:  "devel-8e4a6a4c1b2d",
+			prefix:   "devel-8e4a6a4c1b2d",
+			expected: true,
+		},
+		"ReleaseWithDevelPrefix": {
+			version:  "1.22.0",
+			prefix:   "tip",
+			expected: false,
+		},
 	}
 
 	for testName, testCase := range testCases {
@@ -2,16 +2,75 @@ package semver
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 )
 
-var validSemVerRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+){0,2}$`)
+var (
+	validSemVerRegex     = regexp.MustCompile(`^[0-9]+(\.[0-9]+){0,2}$`)
+	validPreReleaseRegex = regexp.MustCompile(`^[0-9]+\.[0-9]+(beta|rc)[0-9]+$`)
+	preReleaseRegex      = regexp.MustCompile(`^(.*?)(beta|rc)([0-9]+)$`)
+	develRegex           = regexp.MustCompile(`^(tip|devel-[0-9a-f]{7,40})$`)
+)
+
+// Tip is the name of the development version built from the latest commit of
+// a Go source repository.
+const Tip = "tip"
 
 func IsValid(semver string) bool {
-	return validSemVerRegex.MatchString(semver)
+	return validSemVerRegex.MatchString(semver) || validPreReleaseRegex.MatchString(semver) || IsDevel(semver)
+}
+
+// IsDevel returns true if the given version is a development version built from
+// source, either tip or devel-<commit>.
+func IsDevel(version string) bool {
+	return develRegex.MatchString(version)
+}
+
+// IsPreRelease returns true if the given version is a Go pre-release, such as
+// 1.22rc1 or 1.21beta1.
+func IsPreRelease(version string) bool {
+	return validPreReleaseRegex.MatchString(version)
 }
 
 // IsFullVersion returns true if the given version at least contains major, minor and patch segments.
+// Pre-releases and development versions are considered full versions, as they
+// identify a single Go build.
 func IsFullVersion(version string) bool {
-	return IsValid(version) && strings.Count(version, ".") >= 2
+	return IsPreRelease(version) || IsDevel(version) || (IsValid(version) && strings.Count(version, ".") >= 2)
+}
+
+// IsReleaseName returns true if the given version names a single Go release,
+// such as 1.20, 1.21.5 or 1.22rc1, instead of a prefix like 1.
+func IsReleaseName(version string) bool {
+	return IsValid(version) && !IsDevel(version) && strings.Contains(version, ".")
+}
+
+// Minor returns the major and minor line the given version belongs to, e.g.
+// 1.21 for 1.21.5 or 1.22rc1. Development versions don't belong to any line, so
+// they are returned as is.
+func Minor(version string) string {
+	if IsDevel(version) {
+		return version
+	}
+
+	release, _, _ := splitPreRelease(version)
+	segments := strings.SplitN(release, ".", 3)
+	if len(segments) > 2 {
+		segments = segments[:2]
+	}
+
+	return strings.Join(segments, ".")
+}
+
+// splitPreRelease splits given version in its release part and its pre-release
+// kind and number. Kind will be empty if the version is not a pre-release.
+func splitPreRelease(version string) (string, string, int) {
+	matches := preReleaseRegex.FindStringSubmatch(version)
+	if len(matches) != 4 {
+		return version, "", 0
+	}
+
+	number, _ := strconv.Atoi(matches[3])
+	return matches[1], matches[2], number
 }
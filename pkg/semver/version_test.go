@@ -58,6 +58,46 @@ func Test_IsValid(t *testing.T) {
 			semver:   "2.1.2a",
 			expected: false,
 		},
+		"ReleaseCandidate": {
+			semver:   "1.22rc1",
+			expected: true,
+		},
+		"Beta": {
+			semver:   "1.21beta2",
+			expected: true,
+		},
+		"PreReleaseWithoutNumber": {
+			semver:   "1.22rc",
+			expected: false,
+		},
+		"PreReleaseWithoutMinor": {
+			semver:   "1rc1",
+			expected: false,
+		},
+		"PreReleaseAfterPatch": {
+			semver:   "1.22.1rc1",
+			expected: false,
+		},
+		"UnknownPreRelease": {
+			semver:   "1.22alpha1",
+			expected: false,
+		},
+		"Tip": {
+			semver:   "tip",
+			expected: true,
+		},
+		"DevelCommit": {
+			semver:   "devel-8e4a6a4c1b2d",
+			expected: true,
+		},
+		"DevelWithoutCommit": {
+			semver:   "devel-",
+			expected: false,
+		},
+		"DevelWithInvalidCommit": {
+			semver:   "devel-master",
+			expected: false,
+		},
 	}
 
 	for testName, testCase := range testCases {
@@ -85,6 +125,14 @@ func Test_IsFullVersion(t *testing.T) {
 			semver:   "1.2.3",
 			expected: true,
 		},
+		"PreRelease": {
+			semver:   "1.2rc1",
+			expected: true,
+		},
+		"Devel": {
+			semver:   "devel-8e4a6a4c1b2d",
+			expected: true,
+		},
 	}
 
 	for testName, testCase := range testCases {
@@ -94,3 +142,100 @@ func Test_IsFullVersion(t *testing.T) {
 		})
 	}
 }
+
+func Test_IsReleaseName(t *testing.T) {
+	testCases := map[string]struct {
+		semver   string
+		expected bool
+	}{
+		"Major": {
+			semver:   "1",
+			expected: false,
+		},
+		"MajorAndMinor": {
+			semver:   "1.20",
+			expected: true,
+		},
+		"MajorMinorAndPatch": {
+			semver:   "1.2.3",
+			expected: true,
+		},
+		"PreRelease": {
+			semver:   "1.2rc1",
+			expected: true,
+		},
+		"Devel": {
+			semver:   "devel-8e4a6a4c1b2d",
+			expected: false,
+		},
+		"Invalid": {
+			semver:   "1.2.x",
+			expected: false,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			actual := IsReleaseName(testCase.semver)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func Test_IsPreRelease(t *testing.T) {
+	testCases := map[string]struct {
+		semver   string
+		expected bool
+	}{
+		"MajorMinorAndPatch": {
+			semver:   "1.2.3",
+			expected: false,
+		},
+		"ReleaseCandidate": {
+			semver:   "1.2rc1",
+			expected: true,
+		},
+		"Beta": {
+			semver:   "1.2beta1",
+			expected: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			actual := IsPreRelease(testCase.semver)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func Test_Minor(t *testing.T) {
+	testCases := map[string]struct {
+		semver   string
+		expected string
+	}{
+		"Major": {
+			semver:   "1",
+			expected: "1",
+		},
+		"MajorMinorAndPatch": {
+			semver:   "1.21.5",
+			expected: "1.21",
+		},
+		"PreRelease": {
+			semver:   "1.22rc1",
+			expected: "1.22",
+		},
+		"Devel": {
+			semver:   "tip",
+			expected: "tip",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			actual := Minor(testCase.semver)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
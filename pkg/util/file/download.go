@@ -5,71 +5,247 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"hash"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/k0kubun/go-ansi"
 	"github.com/schollz/progressbar/v3"
+	"github.com/sirupsen/logrus"
 	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
 	httputils "github.com/xabierlaiseca/gowrap/pkg/util/http"
 )
 
-func DownloadTo(packageName, dst, url, checksum, algorithm string) error {
-	var hasher hash.Hash
-	switch strings.ToLower(algorithm) {
-	case "sha256":
-		hasher = sha256.New()
-	case "sha1":
-		hasher = sha1.New()
-	case "":
-		hasher = noopHasher{}
+// PartialDownloadSuffix is the suffix of files being downloaded.
+const PartialDownloadSuffix = ".partial"
 
-	default:
-		return customerrors.Errorf("unsupported checksum algorithm: %s", algorithm)
+// initialBackoff is the time to wait before retrying a failed download for the
+// first time, it doubles on every retry up to maxBackoff.
+var initialBackoff = time.Second
+
+const maxBackoff = 30 * time.Second
+
+// DownloadOptions defines how downloads are performed.
+type DownloadOptions struct {
+	// Timeout is the maximum duration of each download attempt.
+	Timeout time.Duration
+	// Retries is the number of times a download is retried after transient
+	// errors.
+	Retries int
+}
+
+// DownloadTo downloads the file in the given URL to dst. Downloads are stored
+// in a partial file next to dst, which is resumed when retrying after
+// transient errors or if left behind by a previous download. The checksum is
+// verified over the complete file.
+func DownloadTo(packageName, dst, url, checksum, algorithm string, options DownloadOptions) error {
+	if _, err := newHasher(algorithm); err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
-	response, err := httputils.Get(ctx, url)
+	fmt.Printf("Downloading %s from %s...\n", packageName, url)
+	partialDst := dst + PartialDownloadSuffix
+	backoff := initialBackoff
+	for attempt := 0; ; attempt++ {
+		err := downloadAttempt(partialDst, url, options.Timeout)
+		if errors.Is(err, errPartialDownloadComplete) {
+			if actualChecksum, checksumErr := Checksum(partialDst, algorithm); checksumErr != nil {
+				return checksumErr
+			} else if actualChecksum == checksum {
+				break
+			}
+
+			_ = os.Remove(partialDst)
+			err = &transientError{err: customerrors.Error("partial download can't be resumed")}
+		}
+
+		if err == nil {
+			break
+		}
+
+		var transientErr *transientError
+		if !errors.As(err, &transientErr) || attempt >= options.Retries {
+			return err
+		}
+
+		logrus.Warningf("failed to download %s, retrying in %v: %v", packageName, backoff, err)
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+
+	actualChecksum, err := Checksum(partialDst, algorithm)
 	if err != nil {
 		return err
 	}
+
+	if actualChecksum != checksum {
+		_ = os.Remove(partialDst)
+		return customerrors.Error("failed to download file, checksums don't match")
+	}
+
+	return os.Rename(partialDst, dst)
+}
+
+// errPartialDownloadComplete is returned when a partial download can't be
+// resumed because it may already contain the complete file.
+var errPartialDownloadComplete = errors.New("partial download is complete")
+
+// downloadAttempt downloads the file into dst, resuming it if partially
+// downloaded.
+func downloadAttempt(dst, url string, timeout time.Duration) error {
+	var offset int64
+	if stat, err := os.Stat(dst); err == nil {
+		offset = stat.Size()
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	response, err := httputils.GetFrom(ctx, url, offset)
+	if err != nil {
+		return &transientError{err: err}
+	}
 	defer response.Body.Close()
 
-	fmt.Printf("Downloading %s from %s...\n", packageName, url)
-	return storeDownload(response, dst, checksum, hasher)
+	flags := os.O_WRONLY | os.O_CREATE
+	switch {
+	case response.StatusCode == http.StatusPartialContent && offset > 0:
+		// appending any other range would corrupt the download
+		if start, _, err := parseContentRange(response.Header.Get("Content-Range")); err != nil || start != offset {
+			_ = os.Remove(dst)
+			return &transientError{err: customerrors.Errorf("unexpected content range: %s", response.Header.Get("Content-Range"))}
+		}
+		flags |= os.O_APPEND
+	case response.StatusCode == http.StatusOK:
+		flags |= os.O_TRUNC
+		offset = 0
+	case response.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// the range starts at the end of the file if already downloaded, which
+		// is verified by its checksum
+		if _, size, err := parseContentRange(response.Header.Get("Content-Range")); offset > 0 && (err != nil || size == offset) {
+			return errPartialDownloadComplete
+		}
+		_ = os.Remove(dst)
+		return &transientError{err: customerrors.Error("partial download can't be resumed")}
+	case response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests:
+		return &transientError{err: customerrors.Errorf("unexpected status: %d", response.StatusCode)}
+	default:
+		return customerrors.Errorf("failed to download file, unexpected status: %d", response.StatusCode)
+	}
+
+	return storeDownload(response, dst, flags, offset)
 }
 
-func storeDownload(response *http.Response, dstPath, expectedChecksum string, hasher hash.Hash) error {
-	dst, err := os.Create(dstPath)
+// parseContentRange returns the start of the range and the size of the
+// complete file in a Content-Range header, e.g. "bytes 3000-5999/6000" or
+// "bytes */6000" for unsatisfied ranges. They are -1 if unknown.
+func parseContentRange(value string) (int64, int64, error) {
+	parts := strings.SplitN(strings.TrimPrefix(value, "bytes "), "/", 2)
+	if !strings.HasPrefix(value, "bytes ") || len(parts) != 2 {
+		return 0, 0, customerrors.Errorf("invalid content range: %s", value)
+	}
+
+	start, size := int64(-1), int64(-1)
+	if rangeSpec := parts[0]; rangeSpec != "*" {
+		parsed, err := strconv.ParseInt(strings.SplitN(rangeSpec, "-", 2)[0], 10, 64)
+		if err != nil {
+			return 0, 0, customerrors.Errorf("invalid content range: %s", value)
+		}
+		start = parsed
+	}
+
+	if sizeSpec := parts[1]; sizeSpec != "*" {
+		parsed, err := strconv.ParseInt(sizeSpec, 10, 64)
+		if err != nil {
+			return 0, 0, customerrors.Errorf("invalid content range: %s", value)
+		}
+		size = parsed
+	}
+
+	return start, size, nil
+}
+
+func storeDownload(response *http.Response, dstPath string, flags int, offset int64) error {
+	dst, err := os.OpenFile(dstPath, flags, 0644)
 	if err != nil {
 		return err
 	}
 	defer dst.Close()
 
-	progressBar, err := newProgressBar(response.ContentLength)
-	if err != nil {
-		return err
+	size := response.ContentLength
+	if size >= 0 {
+		size += offset
 	}
 
-	_, err = io.Copy(io.MultiWriter(dst, hasher, progressBar), response.Body)
+	progressBar, err := newProgressBar(size)
 	if err != nil {
 		return err
 	}
+	_ = progressBar.Set64(offset)
 
-	checksum := hex.EncodeToString(hasher.Sum(nil))
-	if checksum != expectedChecksum {
-		return customerrors.Error("failed to download file, checksums don't match")
+	if _, err = io.Copy(io.MultiWriter(dst, progressBar), response.Body); err != nil {
+		return &transientError{err: err}
 	}
 
 	return nil
 }
 
+// transientError is an error after which a download can be retried.
+type transientError struct {
+	err error
+}
+
+func (te *transientError) Error() string {
+	return te.err.Error()
+}
+
+func (te *transientError) Unwrap() error {
+	return te.err
+}
+
+// Checksum returns the hex encoded checksum of the given file using the given
+// algorithm.
+func Checksum(path, algorithm string) (string, error) {
+	hasher, err := newHasher(algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func newHasher(algorithm string) (hash.Hash, error) {
+	switch strings.ToLower(algorithm) {
+	case "sha256":
+		return sha256.New(), nil
+	case "sha1":
+		return sha1.New(), nil
+	case "":
+		return noopHasher{}, nil
+	}
+
+	return nil, customerrors.Errorf("unsupported checksum algorithm: %s", algorithm)
+}
+
 const progressBarRefreshThrottle = 65 * time.Millisecond
 
 func newProgressBar(max int64) (*progressbar.ProgressBar, error) {
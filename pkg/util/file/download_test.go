@@ -0,0 +1,213 @@
+package file
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DownloadTo_RetriesAndResumes(t *testing.T) {
+	initialBackoff = time.Millisecond
+	content := bytes.Repeat([]byte("gowrap"), 1000)
+	checksum := sha256.Sum256(content)
+
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		switch len(ranges) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			// connection closed after sending half of the content
+			w.Header().Set("Content-Length", "6000")
+			_, _ = w.Write(content[:3000])
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			_ = conn.Close()
+		default:
+			http.ServeContent(w, r, "go.tar.gz", time.Time{}, bytes.NewReader(content))
+		}
+	}))
+	defer server.Close()
+
+	dst := filepath.Join(t.TempDir(), "go.tar.gz")
+	err := DownloadTo("go", dst, server.URL, hex.EncodeToString(checksum[:]), "sha256", DownloadOptions{Timeout: time.Minute, Retries: 3})
+	require.NoError(t, err)
+
+	actual, err := ioutil.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, content, actual)
+	assert.Equal(t, []string{"", "", "bytes=3000-"}, ranges)
+	assert.NoFileExists(t, dst+PartialDownloadSuffix)
+}
+
+func Test_DownloadTo_Failures(t *testing.T) {
+	initialBackoff = time.Millisecond
+	content := []byte("gowrap")
+	checksum := sha256.Sum256(content)
+
+	testCases := map[string]struct {
+		status           int
+		checksum         string
+		expectedRequests int
+		expectedError    string
+	}{
+		"ServerErrorsExhaustRetries": {
+			status:           http.StatusBadGateway,
+			checksum:         hex.EncodeToString(checksum[:]),
+			expectedRequests: 3,
+			expectedError:    "unexpected status: 502",
+		},
+		"ClientErrorsAreNotRetried": {
+			status:           http.StatusNotFound,
+			checksum:         hex.EncodeToString(checksum[:]),
+			expectedRequests: 1,
+			expectedError:    "failed to download file, unexpected status: 404",
+		},
+		"ChecksumMismatch": {
+			status:           http.StatusOK,
+			checksum:         "invalid",
+			expectedRequests: 1,
+			expectedError:    "failed to download file, checksums don't match",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests++
+				w.WriteHeader(testCase.status)
+				_, _ = w.Write(content)
+			}))
+			defer server.Close()
+
+			dst := filepath.Join(t.TempDir(), "go.tar.gz")
+			err := DownloadTo("go", dst, server.URL, testCase.checksum, "sha256", DownloadOptions{Timeout: time.Minute, Retries: 2})
+			assert.EqualError(t, err, testCase.expectedError)
+			assert.Equal(t, testCase.expectedRequests, requests)
+			assert.NoFileExists(t, dst)
+			assert.NoFileExists(t, dst+PartialDownloadSuffix)
+		})
+	}
+}
+
+func Test_DownloadTo_ExistingPartialDownload(t *testing.T) {
+	initialBackoff = time.Millisecond
+	content := bytes.Repeat([]byte("gowrap"), 1000)
+	checksum := sha256.Sum256(content)
+	corrupted := bytes.Repeat([]byte("GOWRAP"), 1000)
+
+	testCases := map[string]struct {
+		partial        []byte
+		contentRange   string
+		expectedRanges []string
+	}{
+		"CompletePartialDownload": {
+			partial:        content,
+			expectedRanges: []string{"bytes=6000-"},
+		},
+		"CorruptedCompletePartialDownload": {
+			partial:        corrupted,
+			expectedRanges: []string{"bytes=6000-", ""},
+		},
+		"TooLongPartialDownload": {
+			partial:        append(append([]byte{}, content...), content...),
+			expectedRanges: []string{"bytes=12000-", ""},
+		},
+		"UnexpectedContentRange": {
+			partial:        content[:3000],
+			contentRange:   "bytes 0-5999/6000",
+			expectedRanges: []string{"bytes=3000-", ""},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			var ranges []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ranges = append(ranges, r.Header.Get("Range"))
+				if len(testCase.contentRange) > 0 && len(r.Header.Get("Range")) > 0 {
+					w.Header().Set("Content-Range", testCase.contentRange)
+					w.WriteHeader(http.StatusPartialContent)
+					_, _ = w.Write(content)
+					return
+				}
+				http.ServeContent(w, r, "go.tar.gz", time.Time{}, bytes.NewReader(content))
+			}))
+			defer server.Close()
+
+			dst := filepath.Join(t.TempDir(), "go.tar.gz")
+			require.NoError(t, ioutil.WriteFile(dst+PartialDownloadSuffix, testCase.partial, 0644))
+
+			err := DownloadTo("go", dst, server.URL, hex.EncodeToString(checksum[:]), "sha256", DownloadOptions{Timeout: time.Minute, Retries: 1})
+			require.NoError(t, err)
+
+			actual, err := ioutil.ReadFile(dst)
+			require.NoError(t, err)
+			assert.Equal(t, content, actual)
+			assert.Equal(t, testCase.expectedRanges, ranges)
+			assert.NoFileExists(t, dst+PartialDownloadSuffix)
+		})
+	}
+}
+
+func Test_parseContentRange(t *testing.T) {
+	testCases := map[string]struct {
+		value         string
+		expectedStart int64
+		expectedSize  int64
+		expectedError bool
+	}{
+		"Range": {
+			value:         "bytes 3000-5999/6000",
+			expectedStart: 3000,
+			expectedSize:  6000,
+		},
+		"UnknownSize": {
+			value:         "bytes 3000-5999/*",
+			expectedStart: 3000,
+			expectedSize:  -1,
+		},
+		"UnsatisfiedRange": {
+			value:         "bytes */6000",
+			expectedStart: -1,
+			expectedSize:  6000,
+		},
+		"Empty": {
+			value:         "",
+			expectedError: true,
+		},
+		"OtherUnit": {
+			value:         "items 0-9/10",
+			expectedError: true,
+		},
+		"InvalidStart": {
+			value:         "bytes x-5999/6000",
+			expectedError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			start, size, err := parseContentRange(testCase.value)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedStart, start)
+			assert.Equal(t, testCase.expectedSize, size)
+		})
+	}
+}
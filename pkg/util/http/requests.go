@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 )
 
@@ -13,3 +14,18 @@ func Get(ctx context.Context, url string) (*http.Response, error) {
 
 	return http.DefaultClient.Do(request)
 }
+
+// GetFrom requests the content in the given URL starting at the given byte
+// offset.
+func GetFrom(ctx context.Context, url string, offset int64) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	return http.DefaultClient.Do(request)
+}
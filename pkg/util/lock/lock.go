@@ -0,0 +1,37 @@
+package lock
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Lock is an exclusive lock held on a file, shared between processes.
+type Lock struct {
+	file *os.File
+}
+
+// Acquire blocks until the exclusive lock on the file in the given path is
+// acquired, creating the file if required.
+func Acquire(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &Lock{file: f}, nil
+}
+
+// Release releases the lock.
+func (l *Lock) Release() error {
+	defer l.file.Close()
+	return unlockFile(l.file)
+}
@@ -0,0 +1,51 @@
+package lock
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Acquire_IsExclusive(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test-lock-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "locks", "test.lock")
+	holders := 0
+	maxHolders := 0
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l, err := Acquire(path)
+			require.NoError(t, err)
+
+			mutex.Lock()
+			holders++
+			if holders > maxHolders {
+				maxHolders = holders
+			}
+			mutex.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mutex.Lock()
+			holders--
+			mutex.Unlock()
+			assert.NoError(t, l.Release())
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, 1, maxHolders)
+}
@@ -0,0 +1,170 @@
+package versions
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mholt/archiver/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	"github.com/xabierlaiseca/gowrap/pkg/util/file"
+	"github.com/xabierlaiseca/gowrap/pkg/versionsfile"
+)
+
+const goVersionFile = "VERSION"
+
+// InstallFromArchive installs the go distribution contained in a local archive.
+// The archive is verified against the checksum in the versions file when known,
+// before extracting it. If version is empty, it is found by the checksum of the
+// archive, or detected from its VERSION file if the checksum is not known.
+// Minor releases named without patch (e.g. 1.20) are installed as their first
+// patch (e.g. 1.20.0), like in the versions file. It returns the version of the archive and `true` if it was installed or
+// `false` if the version was already available.
+func InstallFromArchive(gowrapHome, archivePath, version string) (string, bool, error) {
+	versionsDir, err := GetVersionsDir(gowrapHome)
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(version) > 0 && !semver.IsFullVersion(version) {
+		version = fmt.Sprintf("%s.0", version)
+	}
+
+	version, err = verifyArchiveChecksum(gowrapHome, archivePath, version)
+	if err != nil {
+		return "", false, err
+	}
+
+	stagingDir, err := newStagingDir(gowrapHome)
+	if err != nil {
+		return "", false, err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err := archiver.Unarchive(archivePath, stagingDir); err != nil {
+		return "", false, err
+	}
+
+	goRoot := filepath.Join(stagingDir, "go")
+	if !exists(filepath.Join(goRoot, "bin", "go")) {
+		return "", false, customerrors.Errorf("%s does not contain a go distribution", archivePath)
+	}
+
+	archiveVersion, err := readGoVersionFile(goRoot)
+	switch {
+	case err != nil && len(version) == 0:
+		return "", false, customerrors.Errorf("failed to detect go version of %s: %v", archivePath, err)
+	case err != nil:
+		logrus.Warningf("failed to detect go version of %s: %v", archivePath, err)
+	case len(version) == 0:
+		version = archiveVersion
+	case version != archiveVersion:
+		return "", false, customerrors.Errorf("%s contains go %s instead of %s", archivePath, archiveVersion, version)
+	}
+
+	versionLock, err := lockVersion(gowrapHome, version)
+	if err != nil {
+		return "", false, err
+	}
+	defer versionLock.Release()
+
+	if alreadyInstalled, err := isVersionInstalled(versionsDir, version); err != nil {
+		return "", false, err
+	} else if alreadyInstalled {
+		return version, false, nil
+	}
+
+	if err := installGoRoot(gowrapHome, versionsDir, version, goRoot); err != nil {
+		return "", false, err
+	}
+
+	fmt.Printf("Successfully installed version %s\n", version)
+	return version, true, nil
+}
+
+// readGoVersionFile reads the version of a go distribution from its VERSION
+// file, whose first line contains the version prefixed by "go".
+func readGoVersionFile(goRoot string) (string, error) {
+	content, err := ioutil.ReadFile(filepath.Join(goRoot, goVersionFile))
+	if err != nil {
+		return "", err
+	}
+
+	firstLine := strings.TrimSpace(strings.SplitN(string(content), "\n", 2)[0])
+	version := strings.TrimPrefix(firstLine, "go")
+	if !semver.IsValid(version) {
+		return "", customerrors.Errorf("unexpected version in %s file: %s", goVersionFile, firstLine)
+	}
+
+	if !semver.IsFullVersion(version) {
+		version = fmt.Sprintf("%s.0", version)
+	}
+
+	return version, nil
+}
+
+// verifyArchiveChecksum verifies the archive against the checksum of the given
+// version in the versions file. If version is empty, the version whose
+// checksum matches the archive is returned. Archives without known checksums
+// are only warned about, and an empty version is returned for them if not
+// provided.
+func verifyArchiveChecksum(gowrapHome, archivePath, version string) (string, error) {
+	availableVersions, err := LoadAvailable(gowrapHome)
+	if err != nil {
+		logrus.Warningf("checksum of %s could not be verified: %v", archivePath, err)
+		return version, nil
+	}
+
+	if len(version) == 0 {
+		return findArchiveVersion(availableVersions, archivePath)
+	}
+
+	archive, found := availableVersions[version]
+	if !found {
+		logrus.Warningf("checksum of %s could not be verified, no checksum known for go %s", archivePath, version)
+		return version, nil
+	}
+
+	checksum, err := file.Checksum(archivePath, archive.ChecksumAlgorithm)
+	if err != nil {
+		return "", err
+	}
+
+	if checksum != archive.Checksum {
+		return "", customerrors.Errorf("checksum of %s doesn't match the one of go %s for this platform", archivePath, version)
+	}
+
+	return version, nil
+}
+
+// findArchiveVersion returns the version whose checksum matches the given
+// archive, or an empty version if none matches.
+func findArchiveVersion(availableVersions map[string]versionsfile.GoArchive, archivePath string) (string, error) {
+	checksums := make(map[string]string)
+	for version, archive := range availableVersions {
+		if len(archive.Checksum) == 0 {
+			continue
+		}
+
+		algorithm := strings.ToLower(archive.ChecksumAlgorithm)
+		checksum, computed := checksums[algorithm]
+		if !computed {
+			var err error
+			if checksum, err = file.Checksum(archivePath, archive.ChecksumAlgorithm); err != nil {
+				return "", err
+			}
+			checksums[algorithm] = checksum
+		}
+
+		if checksum == archive.Checksum {
+			return version, nil
+		}
+	}
+
+	logrus.Warningf("checksum of %s could not be verified, no known go version has its checksum", archivePath)
+	return "", nil
+}
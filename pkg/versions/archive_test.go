@@ -0,0 +1,150 @@
+package versions
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/util/file"
+	"github.com/xabierlaiseca/gowrap/pkg/versionsfile"
+)
+
+func Test_ReadGoVersionFile(t *testing.T) {
+	testCases := map[string]struct {
+		content         string
+		expectedVersion string
+		expectedError   bool
+	}{
+		"FullVersion": {
+			content:         "go1.21.5\ntime 2023-11-29T21:21:51Z\n",
+			expectedVersion: "1.21.5",
+		},
+		"MinorVersion": {
+			content:         "go1.20",
+			expectedVersion: "1.20.0",
+		},
+		"PreRelease": {
+			content:         "go1.22rc1\n",
+			expectedVersion: "1.22rc1",
+		},
+		"DevelVersion": {
+			content:       "devel go1.22-8e4a6a4 Mon Dec 4 10:00:00 2023 +0000\n",
+			expectedError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			goRoot, err := ioutil.TempDir(os.TempDir(), "gowrap-test-")
+			require.NoError(t, err)
+			defer os.RemoveAll(goRoot)
+
+			require.NoError(t, ioutil.WriteFile(filepath.Join(goRoot, goVersionFile), []byte(testCase.content), 0600))
+
+			version, err := readGoVersionFile(goRoot)
+			if testCase.expectedError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func Test_InstallFromArchive(t *testing.T) {
+	archivePath := createGoArchive(t, "go1.21.5")
+	minorReleaseArchivePath := createGoArchive(t, "go1.20")
+
+	checksum, err := file.Checksum(archivePath, "sha256")
+	require.NoError(t, err)
+	minorReleaseChecksum, err := file.Checksum(minorReleaseArchivePath, "sha256")
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		archivePath string
+		version     string
+		available   map[string]versionsfile.GoArchive
+
+		expectedVersion string
+		expectedError   bool
+	}{
+		"VersionDetectedFromVersionFile": {
+			archivePath:     archivePath,
+			available:       map[string]versionsfile.GoArchive{},
+			expectedVersion: "1.21.5",
+		},
+		"VersionFoundByChecksum": {
+			archivePath: archivePath,
+			available: map[string]versionsfile.GoArchive{
+				"1.21.4": {Checksum: "invalid", ChecksumAlgorithm: "sha256"},
+				"1.21.5": {Checksum: checksum, ChecksumAlgorithm: "sha256"},
+			},
+			expectedVersion: "1.21.5",
+		},
+		"ChecksumMatch": {
+			archivePath: archivePath,
+			version:     "1.21.5",
+			available: map[string]versionsfile.GoArchive{
+				"1.21.5": {Checksum: checksum, ChecksumAlgorithm: "sha256"},
+			},
+			expectedVersion: "1.21.5",
+		},
+		"ChecksumMismatch": {
+			archivePath: archivePath,
+			version:     "1.21.5",
+			available: map[string]versionsfile.GoArchive{
+				"1.21.5": {Checksum: "invalid", ChecksumAlgorithm: "sha256"},
+			},
+			expectedError: true,
+		},
+		"VersionMismatch": {
+			archivePath:   archivePath,
+			version:       "1.21.6",
+			available:     map[string]versionsfile.GoArchive{},
+			expectedError: true,
+		},
+		"MinorReleaseFoundByChecksum": {
+			archivePath: minorReleaseArchivePath,
+			available: map[string]versionsfile.GoArchive{
+				"1.20.0": {Checksum: minorReleaseChecksum, ChecksumAlgorithm: "sha256"},
+			},
+			expectedVersion: "1.20.0",
+		},
+		"MinorReleaseWithVersion": {
+			archivePath: minorReleaseArchivePath,
+			version:     "1.20",
+			available: map[string]versionsfile.GoArchive{
+				"1.20.0": {Checksum: minorReleaseChecksum, ChecksumAlgorithm: "sha256"},
+			},
+			expectedVersion: "1.20.0",
+		},
+		"MinorReleaseDetectedFromVersionFile": {
+			archivePath:     minorReleaseArchivePath,
+			available:       map[string]versionsfile.GoArchive{},
+			expectedVersion: "1.20.0",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			writeAvailable(t, testCase.available)
+			gowrapHome := t.TempDir()
+
+			version, installed, err := InstallFromArchive(gowrapHome, testCase.archivePath, testCase.version)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				assert.NoDirExists(t, filepath.Join(gowrapHome, "versions", testCase.version))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, installed)
+			assert.Equal(t, testCase.expectedVersion, version)
+			assert.FileExists(t, filepath.Join(gowrapHome, "versions", testCase.expectedVersion, "bin", "go"))
+		})
+	}
+}
@@ -1,15 +1,84 @@
 package versions
 
 import (
+	"github.com/xabierlaiseca/gowrap/pkg/config"
 	"github.com/xabierlaiseca/gowrap/pkg/semver"
 	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
 	"github.com/xabierlaiseca/gowrap/pkg/versionsfile"
 )
 
-func PrintAvailable() error {
-	versionGoArchives, err := versionsfile.Load()
+// LoadAvailable returns the go archives available to install indexed by
+// version, downloading them from the configured mirror if any.
+func LoadAvailable(gowrapHome string) (map[string]versionsfile.GoArchive, error) {
+	c, err := config.Load(gowrapHome)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return versionsfile.Load(versionsFileOptions(c))
+}
+
+// DownloadAvailable downloads the latest versions file, returning the go
+// archives available to install indexed by version.
+func DownloadAvailable(gowrapHome string) (map[string]versionsfile.GoArchive, error) {
+	c, err := config.Load(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	return versionsfile.DownloadToCache(versionsFileOptions(c))
+}
+
+func versionsFileOptions(c *config.Configuration) versionsfile.Options {
+	return versionsfile.Options{
+		Mirror:                    c.GetMirror(),
+		SkipSignatureVerification: c.SignatureVerification == config.SignatureVerificationDisabled,
+	}
+}
+
+// AvailableVersion is a go version available to install.
+type AvailableVersion struct {
+	Version           string `json:"version"`
+	Installed         bool   `json:"installed"`
+	URL               string `json:"url"`
+	Checksum          string `json:"checksum,omitempty"`
+	ChecksumAlgorithm string `json:"checksumAlgorithm,omitempty"`
+}
+
+// supportedMinors is the number of latest minor versions supported by Go, as
+// each major Go release is supported until there are two newer major releases.
+const supportedMinors = 2
+
+// AvailableFilter selects the available versions to describe. The zero value
+// selects all of them.
+type AvailableFilter struct {
+	// Prefix selects the versions with the given prefix.
+	Prefix string
+	// Since selects the versions equal to or newer than the given one.
+	Since string
+	// LatestPerMinor selects only the latest version of each minor version.
+	LatestPerMinor bool
+	// Supported selects only the versions of the minor versions still
+	// supported by Go.
+	Supported bool
+}
+
+// DescribeAvailable returns the go versions available to install selected by
+// the given filter, sorted from oldest to newest.
+func DescribeAvailable(gowrapHome string, filter AvailableFilter) ([]AvailableVersion, error) {
+	versionGoArchives, err := LoadAvailable(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	installed, err := ListInstalled(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	isInstalled := make(map[string]bool, len(installed))
+	for _, version := range installed {
+		isInstalled[version] = true
 	}
 
 	versions := make([]string, 0, len(versionGoArchives))
@@ -17,18 +86,86 @@ func PrintAvailable() error {
 		versions = append(versions, version)
 	}
 
-	return printSortedVersions(versions)
+	versions, err = filterAvailable(versions, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]AvailableVersion, 0, len(versions))
+	for _, version := range versions {
+		archive := versionGoArchives[version]
+		available = append(available, AvailableVersion{
+			Version:           version,
+			Installed:         isInstalled[version],
+			URL:               archive.URL,
+			Checksum:          archive.Checksum,
+			ChecksumAlgorithm: archive.ChecksumAlgorithm,
+		})
+	}
+
+	return available, nil
+}
+
+// filterAvailable returns the given versions selected by filter, sorted from
+// oldest to newest.
+func filterAvailable(versions []string, filter AvailableFilter) ([]string, error) {
+	if err := sortVersions(versions); err != nil {
+		return nil, err
+	}
+
+	var supported map[string]bool
+	if filter.Supported {
+		supported = findSupportedMinors(versions)
+	}
+
+	filtered := make([]string, 0, len(versions))
+	for _, version := range versions {
+		switch {
+		case !semver.HasPrefix(version, filter.Prefix):
+		case len(filter.Since) > 0 && semver.IsLessThan(version, filter.Since):
+		case filter.Supported && !supported[semver.Minor(version)]:
+		default:
+			filtered = append(filtered, version)
+		}
+	}
+
+	if !filter.LatestPerMinor {
+		return filtered, nil
+	}
+
+	// versions are sorted, so the latest of each minor version is the last one
+	latestPerMinor := make([]string, 0, len(filtered))
+	for i, version := range filtered {
+		if i == len(filtered)-1 || semver.Minor(filtered[i+1]) != semver.Minor(version) {
+			latestPerMinor = append(latestPerMinor, version)
+		}
+	}
+
+	return latestPerMinor, nil
+}
+
+// findSupportedMinors returns the latest minor versions with a release among
+// the given sorted versions.
+func findSupportedMinors(sortedVersions []string) map[string]bool {
+	supported := make(map[string]bool, supportedMinors)
+	for i := len(sortedVersions) - 1; i >= 0 && len(supported) < supportedMinors; i-- {
+		if version := sortedVersions[i]; !semver.IsPreRelease(version) && !semver.IsDevel(version) {
+			supported[semver.Minor(version)] = true
+		}
+	}
+
+	return supported
 }
 
-func FindLatestAvailable(prefix string) (string, error) {
-	availableVersions, err := versionsfile.Load()
+func FindLatestAvailable(gowrapHome, prefix string) (string, error) {
+	availableVersions, err := LoadAvailable(gowrapHome)
 	if err != nil {
 		return "", err
 	}
 
 	var compatibleVersions []string
 	for availableVersion := range availableVersions {
-		if semver.HasPrefix(availableVersion, prefix) {
+		if isCandidateFor(availableVersion, prefix) {
 			compatibleVersions = append(compatibleVersions, availableVersion)
 		}
 	}
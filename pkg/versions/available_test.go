@@ -0,0 +1,91 @@
+package versions
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/cache"
+	"github.com/xabierlaiseca/gowrap/pkg/versions/versionstest"
+	"github.com/xabierlaiseca/gowrap/pkg/versionsfile"
+)
+
+func Test_FilterAvailable(t *testing.T) {
+	available := []string{"1.22.1", "1.20.14", "1.21.0", "1.23rc1", "1.21.7", "1.22.0", "1.20.2", "1.22rc2", "1.21.8"}
+
+	testCases := map[string]struct {
+		filter   AvailableFilter
+		expected []string
+	}{
+		"All": {
+			expected: []string{"1.20.2", "1.20.14", "1.21.0", "1.21.7", "1.21.8", "1.22rc2", "1.22.0", "1.22.1", "1.23rc1"},
+		},
+		"Prefix": {
+			filter:   AvailableFilter{Prefix: "1.21"},
+			expected: []string{"1.21.0", "1.21.7", "1.21.8"},
+		},
+		"Since": {
+			filter:   AvailableFilter{Since: "1.21.8"},
+			expected: []string{"1.21.8", "1.22rc2", "1.22.0", "1.22.1", "1.23rc1"},
+		},
+		"LatestPerMinor": {
+			filter:   AvailableFilter{LatestPerMinor: true},
+			expected: []string{"1.20.14", "1.21.8", "1.22.1", "1.23rc1"},
+		},
+		"Supported": {
+			filter:   AvailableFilter{Supported: true},
+			expected: []string{"1.21.0", "1.21.7", "1.21.8", "1.22rc2", "1.22.0", "1.22.1"},
+		},
+		"SupportedLatestPerMinor": {
+			filter:   AvailableFilter{Supported: true, LatestPerMinor: true},
+			expected: []string{"1.21.8", "1.22.1"},
+		},
+		"PrefixAndSince": {
+			filter:   AvailableFilter{Prefix: "1.20", Since: "1.20.3"},
+			expected: []string{"1.20.14"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			versions := append([]string(nil), available...)
+			actual, err := filterAvailable(versions, testCase.filter)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func Test_DescribeAvailable(t *testing.T) {
+	gowrapHome := t.TempDir()
+	versionstest.CreateInstalledVersion(t, gowrapHome, "1.21.8", time.Now())
+
+	archives := map[string]versionsfile.GoArchive{
+		"1.21.7": {URL: "https://go.dev/dl/go1.21.7.linux-amd64.tar.gz", Checksum: "13b7", ChecksumAlgorithm: "sha256"},
+		"1.21.8": {URL: "https://go.dev/dl/go1.21.8.linux-amd64.tar.gz", Checksum: "5377", ChecksumAlgorithm: "sha256"},
+		"1.22.0": {URL: "https://go.dev/dl/go1.22.0.linux-amd64.tar.gz", Checksum: "f6c8", ChecksumAlgorithm: "sha256"},
+	}
+	writeAvailable(t, archives)
+
+	actual, err := DescribeAvailable(gowrapHome, AvailableFilter{Prefix: "1.21"})
+	require.NoError(t, err)
+
+	expected := []AvailableVersion{
+		{Version: "1.21.7", Installed: false, URL: archives["1.21.7"].URL, Checksum: "13b7", ChecksumAlgorithm: "sha256"},
+		{Version: "1.21.8", Installed: true, URL: archives["1.21.8"].URL, Checksum: "5377", ChecksumAlgorithm: "sha256"},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// writeAvailable caches the given archives as the versions file, so they are
+// available without downloading it.
+func writeAvailable(t *testing.T, archives map[string]versionsfile.GoArchive) {
+	t.Setenv("GOWRAP_CACHE_DIR", t.TempDir())
+	t.Setenv("GOWRAP_MIRROR", "")
+
+	content, err := json.Marshal(archives)
+	require.NoError(t, err)
+	require.NoError(t, cache.Set("goversions.json", content, time.Hour))
+}
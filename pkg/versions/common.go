@@ -1,7 +1,6 @@
 package versions
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
@@ -16,16 +15,18 @@ func GetVersionsDir(gowrapHome string) (string, error) {
 	return dir, os.MkdirAll(dir, 0755)
 }
 
-func printSortedVersions(versions []string) error {
+// isCandidateFor returns true if version matches the given prefix. Pre-releases
+// are only candidates when the prefix explicitly asks for a pre-release.
+func isCandidateFor(version, prefix string) bool {
+	return semver.HasPrefix(version, prefix) && (!semver.IsPreRelease(version) || semver.IsPreRelease(prefix))
+}
+
+func sortVersions(versions []string) error {
 	comparator, err := semver.SliceStableComparatorFor(versions)
 	if err != nil {
 		return err
 	}
 
 	sort.SliceStable(versions, comparator)
-	for _, version := range versions {
-		fmt.Println(version)
-	}
-
 	return nil
 }
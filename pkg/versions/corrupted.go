@@ -0,0 +1,62 @@
+package versions
+
+import (
+	"io/ioutil"
+
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+// CorruptedVersion is an entry of the versions directory that is not a usable
+// go installation.
+type CorruptedVersion struct {
+	Version string
+	Reason  string
+}
+
+// FindCorrupted returns the entries of the versions directory that are not
+// usable go installations.
+func FindCorrupted(gowrapHome string) ([]CorruptedVersion, error) {
+	versionsDir, err := GetVersionsDir(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(versionsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrupted []CorruptedVersion
+	for _, f := range files {
+		if !semver.IsValid(f.Name()) {
+			corrupted = append(corrupted, CorruptedVersion{Version: f.Name(), Reason: "it is not a go version"})
+		} else if _, err := isVersionInstalled(versionsDir, f.Name()); err != nil {
+			corrupted = append(corrupted, CorruptedVersion{Version: f.Name(), Reason: err.Error()})
+		}
+	}
+
+	return corrupted, nil
+}
+
+// Reinstall downloads the given version again and atomically replaces the
+// installed one, which is kept if the new install fails. Only versions
+// available in the versions file can be reinstalled.
+func Reinstall(gowrapHome, version string) error {
+	if semver.IsDevel(version) {
+		return customerrors.Errorf("go %s was built from source and can't be reinstalled", version)
+	}
+
+	versionsDir, err := GetVersionsDir(gowrapHome)
+	if err != nil {
+		return err
+	}
+
+	versionLock, err := lockVersion(gowrapHome, version)
+	if err != nil {
+		return err
+	}
+	defer versionLock.Release()
+
+	return installAvailable(gowrapHome, versionsDir, version)
+}
@@ -0,0 +1,33 @@
+package versions
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/versions/versionstest"
+)
+
+func Test_FindCorrupted(t *testing.T) {
+	gowrapHome := t.TempDir()
+	versionstest.CreateInstalledVersion(t, gowrapHome, "1.21.5", time.Now())
+
+	versionsDir := filepath.Join(gowrapHome, "versions")
+	require.NoError(t, os.MkdirAll(filepath.Join(versionsDir, "1.20.3", "bin"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(versionsDir, "1.19.1"), nil, 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(versionsDir, "go"), 0755))
+
+	corrupted, err := FindCorrupted(gowrapHome)
+	require.NoError(t, err)
+
+	var actual []string
+	for _, c := range corrupted {
+		assert.NotEmpty(t, c.Reason)
+		actual = append(actual, c.Version)
+	}
+	assert.ElementsMatch(t, []string{"1.19.1", "1.20.3", "go"}, actual)
+}
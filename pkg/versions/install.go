@@ -2,12 +2,14 @@ package versions
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
 
 	"github.com/mholt/archiver/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/xabierlaiseca/gowrap/pkg/cache"
+	"github.com/xabierlaiseca/gowrap/pkg/config"
 	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
 	"github.com/xabierlaiseca/gowrap/pkg/util/file"
 	"github.com/xabierlaiseca/gowrap/pkg/versionsfile"
@@ -17,7 +19,7 @@ import (
 // If no error, `true` will be returned if the version was installed or `false` if the version
 // was already available.
 func InstallLatestIfNotInstalled(gowrapHome, prefix string) (bool, error) {
-	versionToInstall, err := FindLatestAvailable(prefix)
+	versionToInstall, err := FindLatestAvailable(gowrapHome, prefix)
 	if err != nil {
 		return false, err
 	}
@@ -27,7 +29,8 @@ func InstallLatestIfNotInstalled(gowrapHome, prefix string) (bool, error) {
 
 // InstallIfNotInstalled installs the requested version if not already installed.
 // If no error, `true` will be returned if the version was installed or `false` if the version
-// was already available.
+// was already available. Concurrent installs of the same version wait for the first one to
+// finish and reuse it.
 func InstallIfNotInstalled(gowrapHome, version string) (bool, error) {
 	versionsDir, err := GetVersionsDir(gowrapHome)
 	if err != nil {
@@ -40,50 +43,112 @@ func InstallIfNotInstalled(gowrapHome, version string) (bool, error) {
 		return false, nil
 	}
 
-	installableVersions, err := versionsfile.Load()
+	versionLock, err := lockVersion(gowrapHome, version)
 	if err != nil {
 		return false, err
 	}
+	defer versionLock.Release()
 
-	archive, found := installableVersions[version]
-	if !found {
-		return false, customerrors.Errorf("version %s is not available", version)
+	if alreadyInstalled, err := isVersionInstalled(versionsDir, version); err != nil {
+		return false, err
+	} else if alreadyInstalled {
+		return false, nil
 	}
 
-	destinationDir := filepath.Join(versionsDir, version)
-	if err := unarchiveRemoteFile(archive, destinationDir); err != nil {
+	if err := installAvailable(gowrapHome, versionsDir, version); err != nil {
 		return false, err
 	}
 
-	fmt.Printf("Successfully installed version %s\n", version)
 	return true, nil
 }
 
-func unarchiveRemoteFile(archive versionsfile.GoArchive, destinationDir string) error {
-	filename := path.Base(archive.URL)
-	downloadsDir, downloadsDirSet := os.LookupEnv("GOWRAP_DOWNLOADS_DIR")
-	if !downloadsDirSet {
-		var err error
-		downloadsDir, err = ioutil.TempDir(os.TempDir(), "go-download-")
-		if err != nil {
-			return err
-		}
+// installAvailable downloads the given version from the versions file and
+// installs it, replacing any previous install of the version. The caller must
+// hold the lock of the version.
+func installAvailable(gowrapHome, versionsDir, version string) error {
+	installableVersions, err := LoadAvailable(gowrapHome)
+	if err != nil {
+		return err
+	}
 
-		defer os.RemoveAll(downloadsDir)
+	archive, found := installableVersions[version]
+	if !found {
+		return customerrors.Errorf("version %s is not available", version)
 	}
 
-	archiveDst := filepath.Join(downloadsDir, filename)
-	if !downloadsDirSet || !exists(archiveDst) {
-		if err := file.DownloadTo("go", archiveDst, archive.URL, archive.Checksum, archive.ChecksumAlgorithm); err != nil {
-			return err
-		}
+	c, err := config.Load(gowrapHome)
+	if err != nil {
+		return err
 	}
 
-	if err := archiver.Unarchive(archiveDst, downloadsDir); err != nil {
+	timeout, err := c.GetDownloadTimeout()
+	if err != nil {
+		return err
+	}
+	downloadOptions := file.DownloadOptions{Timeout: timeout, Retries: c.DownloadRetries}
+
+	stagingDir, err := newStagingDir(gowrapHome)
+	if err != nil {
 		return err
 	}
+	defer os.RemoveAll(stagingDir)
+
+	goRoot, err := unarchiveRemoteFile(archive, stagingDir, downloadOptions)
+	if err != nil {
+		return err
+	}
+
+	if err := installGoRoot(gowrapHome, versionsDir, version, goRoot); err != nil {
+		return err
+	}
+
+	archiveLimits := cache.ArchiveLimits{MaxSize: c.GetArchiveCacheMaxSize(), MaxAge: c.GetArchiveCacheMaxAge()}
+	if _, err := cache.PruneArchives(archiveLimits); err != nil {
+		logrus.Warningf("failed to prune cached archives: %v", err)
+	}
+
+	fmt.Printf("Successfully installed version %s\n", version)
+	return nil
+}
+
+// unarchiveRemoteFile downloads and unarchives the given archive into the
+// staging directory, returning the path of the unarchived go root.
+func unarchiveRemoteFile(archive versionsfile.GoArchive, stagingDir string, downloadOptions file.DownloadOptions) (string, error) {
+	archiveDst, err := downloadArchive(archive, stagingDir, downloadOptions)
+	if err != nil {
+		return "", err
+	}
+
+	unarchiveDir := filepath.Join(stagingDir, "unarchived")
+	if err := archiver.Unarchive(archiveDst, unarchiveDir); err != nil {
+		return "", err
+	}
 
-	return os.Rename(filepath.Join(downloadsDir, "go"), destinationDir)
+	return filepath.Join(unarchiveDir, "go"), nil
+}
+
+// downloadArchive returns the path of the given archive, downloading it into
+// the archives cache unless already cached. Archives without checksum can't
+// be cached, so they are downloaded into the staging directory.
+func downloadArchive(archive versionsfile.GoArchive, stagingDir string, downloadOptions file.DownloadOptions) (string, error) {
+	filename := path.Base(archive.URL)
+	if len(archive.ChecksumAlgorithm) == 0 {
+		archiveDst := filepath.Join(stagingDir, filename)
+		return archiveDst, file.DownloadTo("go", archiveDst, archive.URL, archive.Checksum, archive.ChecksumAlgorithm, downloadOptions)
+	}
+
+	if cachedPath, found, err := cache.GetArchive(filename, archive.Checksum, archive.ChecksumAlgorithm); err != nil {
+		return "", err
+	} else if found {
+		return cachedPath, nil
+	}
+
+	archiveDst, err := cache.NewArchivePath(filename, archive.Checksum, archive.ChecksumAlgorithm)
+	if err != nil {
+		return "", err
+	}
+
+	return archiveDst, file.DownloadTo("go", archiveDst, archive.URL, archive.Checksum, archive.ChecksumAlgorithm, downloadOptions)
 }
 
 func Uninstall(gowrapHome, version string) error {
@@ -92,6 +157,12 @@ func Uninstall(gowrapHome, version string) error {
 		return err
 	}
 
+	versionLock, err := lockVersion(gowrapHome, version)
+	if err != nil {
+		return err
+	}
+	defer versionLock.Release()
+
 	versionDir := filepath.Join(versionsDir, version)
 
 	if _, err = os.Stat(versionDir); os.IsNotExist(err) {
@@ -100,7 +171,22 @@ func Uninstall(gowrapHome, version string) error {
 		return err
 	}
 
-	return os.RemoveAll(versionDir)
+	stagingDir, err := newStagingDir(gowrapHome)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	// moved out first, so the version disappears atomically
+	if err := os.Rename(versionDir, filepath.Join(stagingDir, version)); err != nil {
+		return err
+	}
+
+	if err := removeManifest(gowrapHome, version); err != nil {
+		return err
+	}
+
+	return removeUsage(gowrapHome, version)
 }
 
 func isVersionInstalled(versionsDir, version string) (bool, error) {
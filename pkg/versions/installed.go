@@ -2,6 +2,7 @@ package versions
 
 import (
 	"io/ioutil"
+	"path/filepath"
 
 	"github.com/xabierlaiseca/gowrap/pkg/semver"
 	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
@@ -40,7 +41,7 @@ func FindLatestInstalledForPrefix(gowrapHome, prefix string) (string, error) {
 
 	var compatibleVersions []string
 	for _, installedVersion := range installedVersions {
-		if semver.HasPrefix(installedVersion, prefix) {
+		if isCandidateFor(installedVersion, prefix) {
 			compatibleVersions = append(compatibleVersions, installedVersion)
 		}
 	}
@@ -52,11 +53,45 @@ func FindLatestInstalledForPrefix(gowrapHome, prefix string) (string, error) {
 	return semver.Latest(compatibleVersions)
 }
 
-func PrintInstalled(gowrapHome string) error {
+// InstalledVersion is an installed go version.
+type InstalledVersion struct {
+	Version string `json:"version"`
+	Path    string `json:"path"`
+	// Usage is the last usage of the version, nil if its usage was never
+	// recorded.
+	Usage *Usage `json:"usage,omitempty"`
+}
+
+// DescribeInstalled returns the installed go versions, sorted from oldest to
+// newest.
+func DescribeInstalled(gowrapHome string) ([]InstalledVersion, error) {
+	versionsDir, err := GetVersionsDir(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
 	versions, err := ListInstalled(gowrapHome)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if err := sortVersions(versions); err != nil {
+		return nil, err
+	}
+
+	installed := make([]InstalledVersion, 0, len(versions))
+	for _, version := range versions {
+		usage, err := GetUsage(gowrapHome, version)
+		if err != nil {
+			return nil, err
+		}
+
+		installed = append(installed, InstalledVersion{
+			Version: version,
+			Path:    filepath.Join(versionsDir, version),
+			Usage:   usage,
+		})
 	}
 
-	return printSortedVersions(versions)
+	return installed, nil
 }
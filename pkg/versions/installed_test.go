@@ -0,0 +1,30 @@
+package versions
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/versions/versionstest"
+)
+
+func Test_DescribeInstalled(t *testing.T) {
+	gowrapHome := t.TempDir()
+	versionstest.CreateInstalledVersion(t, gowrapHome, "1.21.10", time.Now())
+	versionstest.CreateInstalledVersion(t, gowrapHome, "1.21.9", time.Now())
+	require.NoError(t, RecordUsage(gowrapHome, "1.21.9", "/projects/a"))
+
+	installed, err := DescribeInstalled(gowrapHome)
+	require.NoError(t, err)
+	require.Len(t, installed, 2)
+
+	assert.Equal(t, "1.21.9", installed[0].Version)
+	assert.Equal(t, filepath.Join(gowrapHome, "versions", "1.21.9"), installed[0].Path)
+	require.NotNil(t, installed[0].Usage)
+	assert.Equal(t, "/projects/a", installed[0].Usage.ProjectRoot)
+
+	assert.Equal(t, "1.21.10", installed[1].Version)
+	assert.Nil(t, installed[1].Usage)
+}
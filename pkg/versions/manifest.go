@@ -0,0 +1,156 @@
+package versions
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	"github.com/xabierlaiseca/gowrap/pkg/util/file"
+)
+
+const (
+	manifestsDir      = "manifests"
+	manifestAlgorithm = "sha256"
+	symlinkPrefix     = "symlink:"
+)
+
+// manifest contains the hashes of the files of an installed version, by their
+// slash separated path relative to the version directory.
+type manifest struct {
+	Algorithm string            `json:"algorithm"`
+	Files     map[string]string `json:"files"`
+}
+
+// Verification is the result of comparing an installed version with the
+// manifest recorded when it was installed.
+type Verification struct {
+	Version string
+	Added   []string
+	Changed []string
+	Missing []string
+}
+
+// IsValid returns true if the installed version matches its manifest.
+func (v *Verification) IsValid() bool {
+	return len(v.Added) == 0 && len(v.Changed) == 0 && len(v.Missing) == 0
+}
+
+// Verify compares the files of the given installed version with the manifest
+// recorded when it was installed. A not found error is returned if no manifest
+// was recorded for the version.
+func Verify(gowrapHome, version string) (*Verification, error) {
+	versionsDir, err := GetVersionsDir(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	if installed, err := isVersionInstalled(versionsDir, version); err != nil {
+		return nil, err
+	} else if !installed {
+		return nil, customerrors.Errorf("version %s is not installed", version)
+	}
+
+	expected, err := readManifest(gowrapHome, version)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, err := buildManifest(filepath.Join(versionsDir, version), expected.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	verification := &Verification{Version: version}
+	for path, hash := range actual.Files {
+		if expectedHash, found := expected.Files[path]; !found {
+			verification.Added = append(verification.Added, path)
+		} else if expectedHash != hash {
+			verification.Changed = append(verification.Changed, path)
+		}
+	}
+
+	for path := range expected.Files {
+		if _, found := actual.Files[path]; !found {
+			verification.Missing = append(verification.Missing, path)
+		}
+	}
+
+	sort.Strings(verification.Added)
+	sort.Strings(verification.Changed)
+	sort.Strings(verification.Missing)
+	return verification, nil
+}
+
+func storeManifest(gowrapHome, version string, m *manifest) error {
+	content, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	manifestPath := buildManifestPath(gowrapHome, version)
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(manifestPath, content, 0644)
+}
+
+func readManifest(gowrapHome, version string) (*manifest, error) {
+	content, err := ioutil.ReadFile(buildManifestPath(gowrapHome, version))
+	if os.IsNotExist(err) {
+		return nil, customerrors.NotFound()
+	} else if err != nil {
+		return nil, err
+	}
+
+	m := &manifest{}
+	if err := json.Unmarshal(content, m); err != nil {
+		return nil, customerrors.Errorf("corrupted manifest of version %s: %v", version, err)
+	}
+
+	return m, nil
+}
+
+func removeManifest(gowrapHome, version string) error {
+	if err := os.Remove(buildManifestPath(gowrapHome, version)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func buildManifest(root, algorithm string) (*manifest, error) {
+	m := &manifest{Algorithm: algorithm, Files: make(map[string]string)}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		var hash string
+		if info.Mode()&os.ModeSymlink != 0 {
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			hash = symlinkPrefix + filepath.ToSlash(target)
+		} else if hash, err = file.Checksum(path, algorithm); err != nil {
+			return err
+		}
+
+		m.Files[filepath.ToSlash(relPath)] = hash
+		return nil
+	})
+
+	return m, err
+}
+
+func buildManifestPath(gowrapHome, version string) string {
+	return filepath.Join(gowrapHome, manifestsDir, version+".json")
+}
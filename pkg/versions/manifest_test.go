@@ -0,0 +1,87 @@
+package versions
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	"github.com/xabierlaiseca/gowrap/pkg/versions/versionstest"
+)
+
+func Test_Verify(t *testing.T) {
+	testCases := map[string]struct {
+		modify          func(t *testing.T, versionDir string)
+		expectedAdded   []string
+		expectedChanged []string
+		expectedMissing []string
+	}{
+		"Unmodified": {
+			modify: func(*testing.T, string) {},
+		},
+		"AddedFile": {
+			modify: func(t *testing.T, versionDir string) {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(versionDir, "bin", "stringer"), []byte("binary"), 0755))
+			},
+			expectedAdded: []string{"bin/stringer"},
+		},
+		"ChangedFile": {
+			modify: func(t *testing.T, versionDir string) {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(versionDir, "src", "fmt", "print.go"), []byte("package evil\n"), 0644))
+			},
+			expectedChanged: []string{"src/fmt/print.go"},
+		},
+		"MissingFile": {
+			modify: func(t *testing.T, versionDir string) {
+				require.NoError(t, os.Remove(filepath.Join(versionDir, "bin", "gofmt")))
+			},
+			expectedMissing: []string{"bin/gofmt"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			gowrapHome := t.TempDir()
+			versionsDir, err := GetVersionsDir(gowrapHome)
+			require.NoError(t, err)
+
+			goRoot := filepath.Join(t.TempDir(), "go")
+			writeFiles(t, goRoot, map[string]string{
+				"bin/go":           "go binary",
+				"bin/gofmt":        "gofmt binary",
+				"src/fmt/print.go": "package fmt\n",
+				"VERSION":          "go1.21.5\n",
+			})
+			require.NoError(t, installGoRoot(gowrapHome, versionsDir, "1.21.5", goRoot))
+
+			testCase.modify(t, filepath.Join(versionsDir, "1.21.5"))
+
+			verification, err := Verify(gowrapHome, "1.21.5")
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedAdded, verification.Added)
+			assert.Equal(t, testCase.expectedChanged, verification.Changed)
+			assert.Equal(t, testCase.expectedMissing, verification.Missing)
+			assert.Equal(t, len(testCase.expectedAdded)+len(testCase.expectedChanged)+len(testCase.expectedMissing) == 0, verification.IsValid())
+		})
+	}
+}
+
+func Test_Verify_WithoutManifest(t *testing.T) {
+	gowrapHome := t.TempDir()
+	versionstest.CreateInstalledVersion(t, gowrapHome, "1.21.5", time.Now())
+
+	_, err := Verify(gowrapHome, "1.21.5")
+	assert.True(t, customerrors.IsNotFound(err))
+}
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for path, content := range files {
+		filePath := filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+		require.NoError(t, ioutil.WriteFile(filePath, []byte(content), 0755))
+	}
+}
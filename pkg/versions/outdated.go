@@ -0,0 +1,88 @@
+package versions
+
+import (
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+// Outdated compares a version in use with the latest available versions.
+type Outdated struct {
+	// Source is where the version is used from, e.g. installed, default or
+	// project.
+	Source  string
+	Current string
+	// LatestPatch is the latest available version of the minor line of the
+	// current version, empty if there is none.
+	LatestPatch string
+	// LatestMinor is the latest available version.
+	LatestMinor string
+}
+
+// IsBehindPatch returns true if a newer patch of the current version is
+// available.
+func (o *Outdated) IsBehindPatch() bool {
+	return semver.IsFullVersion(o.Current) && len(o.LatestPatch) > 0 && semver.IsLessThan(o.Current, o.LatestPatch)
+}
+
+// IsBehindMinor returns true if a newer minor version than the current one is
+// available.
+func (o *Outdated) IsBehindMinor() bool {
+	return !semver.IsDevel(o.Current) && len(o.LatestMinor) > 0 &&
+		semver.Minor(o.Current) != semver.Minor(o.LatestMinor) && semver.IsLessThan(o.Current, o.LatestMinor)
+}
+
+// CheckOutdated compares the given version with the latest available ones.
+func CheckOutdated(gowrapHome, source, current string) (*Outdated, error) {
+	outdated := &Outdated{Source: source, Current: current}
+	if semver.IsDevel(current) {
+		return outdated, nil
+	}
+
+	var err error
+	if outdated.LatestPatch, err = FindLatestAvailable(gowrapHome, semver.Minor(current)); err != nil && !customerrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if outdated.LatestMinor, err = FindLatestAvailable(gowrapHome, ""); err != nil && !customerrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	return outdated, nil
+}
+
+// CheckOutdatedInstalled compares the latest installed version of every
+// installed minor line with the latest available ones.
+func CheckOutdatedInstalled(gowrapHome string) ([]*Outdated, error) {
+	installed, err := ListInstalled(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	minors, err := installedMinors(installed)
+	if err != nil {
+		return nil, err
+	}
+
+	outdated := make([]*Outdated, 0, len(minors))
+	for _, minor := range minors {
+		var inMinor []string
+		for _, version := range installed {
+			if !semver.IsDevel(version) && semver.Minor(version) == minor {
+				inMinor = append(inMinor, version)
+			}
+		}
+
+		current, err := semver.Latest(inMinor)
+		if err != nil {
+			return nil, err
+		}
+
+		o, err := CheckOutdated(gowrapHome, "installed", current)
+		if err != nil {
+			return nil, err
+		}
+		outdated = append(outdated, o)
+	}
+
+	return outdated, nil
+}
@@ -0,0 +1,53 @@
+package versions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Outdated(t *testing.T) {
+	testCases := map[string]struct {
+		outdated            Outdated
+		expectedBehindPatch bool
+		expectedBehindMinor bool
+	}{
+		"UpToDate": {
+			outdated: Outdated{Current: "1.22.3", LatestPatch: "1.22.3", LatestMinor: "1.22.3"},
+		},
+		"BehindPatch": {
+			outdated:            Outdated{Current: "1.22.1", LatestPatch: "1.22.3", LatestMinor: "1.22.3"},
+			expectedBehindPatch: true,
+		},
+		"BehindMinor": {
+			outdated:            Outdated{Current: "1.21.8", LatestPatch: "1.21.8", LatestMinor: "1.22.3"},
+			expectedBehindMinor: true,
+		},
+		"BehindPatchAndMinor": {
+			outdated:            Outdated{Current: "1.21.2", LatestPatch: "1.21.8", LatestMinor: "1.22.3"},
+			expectedBehindPatch: true,
+			expectedBehindMinor: true,
+		},
+		"PrefixNeverBehindPatch": {
+			outdated:            Outdated{Current: "1.21", LatestPatch: "1.21.8", LatestMinor: "1.22.3"},
+			expectedBehindMinor: true,
+		},
+		"PreReleaseBehindRelease": {
+			outdated:            Outdated{Current: "1.22rc1", LatestPatch: "1.22.0", LatestMinor: "1.22.0"},
+			expectedBehindPatch: true,
+		},
+		"DevelVersion": {
+			outdated: Outdated{Current: "tip", LatestMinor: "1.22.3"},
+		},
+		"NoPatchAvailable": {
+			outdated: Outdated{Current: "1.23rc1", LatestMinor: "1.22.3"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedBehindPatch, testCase.outdated.IsBehindPatch())
+			assert.Equal(t, testCase.expectedBehindMinor, testCase.outdated.IsBehindMinor())
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package versions
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+)
+
+const develLine = "devel"
+
+// PrunePolicy defines which installed versions are kept when pruning. A version
+// is kept if any of the rules keeps it.
+type PrunePolicy struct {
+	// KeepPatches is the number of latest versions kept per major and minor
+	// line. Development versions are considered a single line.
+	KeepPatches int
+	// KeepUsedWithin keeps the versions used within the given duration, no
+	// version is kept by this rule if zero.
+	KeepUsedWithin time.Duration
+}
+
+// Prune removes the installed versions not kept by the given policy, except the
+// protected ones. If dryRun is set, nothing is removed. It returns the removed
+// versions, sorted.
+func Prune(gowrapHome string, policy PrunePolicy, protected []string, dryRun bool) ([]string, error) {
+	installed, err := ListInstalled(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	comparator, err := semver.SliceStableComparatorFor(installed)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(installed, comparator)
+
+	keep := make(map[string]bool)
+	for _, version := range protected {
+		keep[version] = true
+	}
+
+	keptPerLine := make(map[string]int)
+	for i := len(installed) - 1; i >= 0; i-- {
+		line := semver.Minor(installed[i])
+		if semver.IsDevel(installed[i]) {
+			line = develLine
+		}
+
+		if keptPerLine[line] < policy.KeepPatches {
+			keptPerLine[line]++
+			keep[installed[i]] = true
+		}
+	}
+
+	var pruned []string
+	for _, version := range installed {
+		if keep[version] {
+			continue
+		}
+
+		if lastUsed, err := LastUsed(gowrapHome, version); err != nil {
+			return pruned, err
+		} else if policy.KeepUsedWithin > 0 && time.Since(lastUsed) <= policy.KeepUsedWithin {
+			continue
+		}
+
+		if !dryRun {
+			if err := Uninstall(gowrapHome, version); err != nil {
+				return pruned, err
+			}
+		}
+		pruned = append(pruned, version)
+	}
+
+	return pruned, nil
+}
+
+// LastUsed returns the last time the given installed version was used.
+// Versions without recorded usage are considered used when installed.
+func LastUsed(gowrapHome, version string) (time.Time, error) {
+	if usage, err := GetUsage(gowrapHome, version); err != nil {
+		return time.Time{}, err
+	} else if usage != nil {
+		return usage.LastUsed, nil
+	}
+
+	versionsDir, err := GetVersionsDir(gowrapHome)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	stat, err := os.Stat(filepath.Join(versionsDir, version))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return stat.ModTime(), nil
+}
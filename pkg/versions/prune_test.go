@@ -0,0 +1,74 @@
+package versions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/versions/versionstest"
+)
+
+func Test_Prune(t *testing.T) {
+	testCases := map[string]struct {
+		policy            PrunePolicy
+		protected         []string
+		dryRun            bool
+		expectedPruned    []string
+		expectedInstalled []string
+	}{
+		"KeepLatestPatch": {
+			policy:            PrunePolicy{KeepPatches: 1},
+			expectedPruned:    []string{"1.20.1", "1.20.2", "1.21.4", "devel-8e4a6a4c1b2d"},
+			expectedInstalled: []string{"1.20.3", "1.21.5", "tip"},
+		},
+		"KeepLatestTwoPatches": {
+			policy:            PrunePolicy{KeepPatches: 2},
+			expectedPruned:    []string{"1.20.1"},
+			expectedInstalled: []string{"1.20.2", "1.20.3", "1.21.4", "1.21.5", "devel-8e4a6a4c1b2d", "tip"},
+		},
+		"KeepUsedRecently": {
+			policy:            PrunePolicy{KeepPatches: 1, KeepUsedWithin: 7 * 24 * time.Hour},
+			expectedPruned:    []string{"1.20.1", "1.21.4", "devel-8e4a6a4c1b2d"},
+			expectedInstalled: []string{"1.20.2", "1.20.3", "1.21.5", "tip"},
+		},
+		"KeepProtected": {
+			policy:            PrunePolicy{KeepPatches: 1},
+			protected:         []string{"1.20.1"},
+			expectedPruned:    []string{"1.20.2", "1.21.4", "devel-8e4a6a4c1b2d"},
+			expectedInstalled: []string{"1.20.1", "1.20.3", "1.21.5", "tip"},
+		},
+		"DryRun": {
+			policy:            PrunePolicy{KeepPatches: 1},
+			dryRun:            true,
+			expectedPruned:    []string{"1.20.1", "1.20.2", "1.21.4", "devel-8e4a6a4c1b2d"},
+			expectedInstalled: []string{"1.20.1", "1.20.2", "1.20.3", "1.21.4", "1.21.5", "devel-8e4a6a4c1b2d", "tip"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			gowrapHome := t.TempDir()
+			old := time.Now().Add(-30 * 24 * time.Hour)
+			for version, lastUsed := range map[string]time.Time{
+				"1.20.1":             old,
+				"1.20.2":             time.Now(),
+				"1.20.3":             old,
+				"1.21.4":             old,
+				"1.21.5":             old,
+				"devel-8e4a6a4c1b2d": old,
+				"tip":                old,
+			} {
+				versionstest.CreateInstalledVersion(t, gowrapHome, version, lastUsed)
+			}
+
+			pruned, err := Prune(gowrapHome, testCase.policy, testCase.protected, testCase.dryRun)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedPruned, pruned)
+
+			installed, err := ListInstalled(gowrapHome)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, testCase.expectedInstalled, installed)
+		})
+	}
+}
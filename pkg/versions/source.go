@@ -0,0 +1,154 @@
+package versions
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+const (
+	develVersionPrefix = "devel-"
+	develCommitLength  = 12
+
+	goRootBootstrapEnvVar = "GOROOT_BOOTSTRAP"
+)
+
+// InstallFromSource builds go from the given git repository, which can be a
+// local checkout or a URL, bootstrapping it with the latest installed version.
+// If ref is empty, the default branch is built and installed as tip, replacing
+// any previous tip. Otherwise, ref is installed as devel-<commit>. It returns
+// the installed version and `true` if it was installed or `false` if the
+// version was already available.
+func InstallFromSource(gowrapHome, repository, ref string) (string, bool, error) {
+	versionsDir, err := GetVersionsDir(gowrapHome)
+	if err != nil {
+		return "", false, err
+	}
+
+	bootstrapVersion, err := FindLatestInstalled(gowrapHome)
+	if customerrors.IsNotFound(err) {
+		return "", false, customerrors.Error("a go version is required to bootstrap the build, run 'gowrap install <version>' to install one")
+	} else if err != nil {
+		return "", false, err
+	}
+
+	buildDir, err := newStagingDir(gowrapHome)
+	if err != nil {
+		return "", false, err
+	}
+	defer os.RemoveAll(buildDir)
+
+	goRoot := filepath.Join(buildDir, "go")
+	if err := runGit(buildDir, "clone", "--quiet", repository, goRoot); err != nil {
+		return "", false, err
+	}
+
+	if len(ref) > 0 {
+		if err := runGit(goRoot, "checkout", "--quiet", ref); err != nil {
+			return "", false, err
+		}
+	}
+
+	version, err := develVersionFor(goRoot, ref)
+	if err != nil {
+		return "", false, err
+	}
+
+	if version != semver.Tip {
+		if alreadyInstalled, err := isVersionInstalled(versionsDir, version); err != nil {
+			return "", false, err
+		} else if alreadyInstalled {
+			return version, false, nil
+		}
+	}
+
+	fmt.Printf("Building go %s with go %s...\n", version, bootstrapVersion)
+	if err := runMake(goRoot, filepath.Join(versionsDir, bootstrapVersion)); err != nil {
+		return "", false, err
+	}
+
+	versionLock, err := lockVersion(gowrapHome, version)
+	if err != nil {
+		return "", false, err
+	}
+	defer versionLock.Release()
+
+	if err := installGoRoot(gowrapHome, versionsDir, version, goRoot); err != nil {
+		return "", false, err
+	}
+
+	fmt.Printf("Successfully installed version %s\n", version)
+	return version, true, nil
+}
+
+func develVersionFor(goRoot, ref string) (string, error) {
+	if len(ref) == 0 {
+		return semver.Tip, nil
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = goRoot
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", customerrors.Errorf("failed to get checked out commit: %v", err)
+	}
+
+	commit := strings.TrimSpace(stdout.String())
+	if len(commit) > develCommitLength {
+		commit = commit[:develCommitLength]
+	}
+
+	return develVersionPrefix + commit, nil
+}
+
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return customerrors.Errorf("git %s failed: %v", args[0], err)
+	}
+
+	return nil
+}
+
+func runMake(goRoot, bootstrapGoRoot string) error {
+	script := "./make.bash"
+	if runtime.GOOS == "windows" {
+		script = "make.bat"
+	}
+
+	cmd := exec.Command(script)
+	cmd.Dir = filepath.Join(goRoot, "src")
+	cmd.Env = append(withoutGoRoot(os.Environ()), goRootBootstrapEnvVar+"="+bootstrapGoRoot)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return customerrors.Errorf("failed to build go: %v", err)
+	}
+
+	return nil
+}
+
+// withoutGoRoot removes the variables that would make the build use another
+// go installation.
+func withoutGoRoot(environ []string) []string {
+	filtered := make([]string, 0, len(environ))
+	for _, kv := range environ {
+		if !strings.HasPrefix(kv, "GOROOT=") && !strings.HasPrefix(kv, goRootBootstrapEnvVar+"=") {
+			filtered = append(filtered, kv)
+		}
+	}
+
+	return filtered
+}
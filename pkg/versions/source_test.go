@@ -0,0 +1,190 @@
+package versions
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/versions/versionstest"
+)
+
+// fakeMakeScript fakes the go build, creating the go binary and storing the
+// build environment next to it.
+const fakeMakeScript = `#!/bin/sh
+mkdir -p ../bin
+printf '#!/bin/sh\n' > ../bin/go
+chmod +x ../bin/go
+env > ../build.env
+`
+
+func Test_InstallFromSource(t *testing.T) {
+	skipWithoutGit(t)
+
+	repository := createGoRepository(t)
+	firstCommit := gitOutput(t, repository, "rev-parse", "HEAD")
+	commitFile(t, repository, "README", "second commit")
+
+	testCases := map[string]struct {
+		ref             string
+		expectedVersion string
+	}{
+		"DefaultBranch": {
+			ref:             "",
+			expectedVersion: semver.Tip,
+		},
+		"Commit": {
+			ref:             firstCommit,
+			expectedVersion: develVersionPrefix + firstCommit[:develCommitLength],
+		},
+		"ShortCommit": {
+			ref:             firstCommit[:7],
+			expectedVersion: develVersionPrefix + firstCommit[:develCommitLength],
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			t.Setenv("GOROOT", "/usr/local/go")
+			t.Setenv(goRootBootstrapEnvVar, "/usr/local/go")
+
+			gowrapHome := t.TempDir()
+			versionstest.CreateInstalledVersion(t, gowrapHome, "1.21.5", time.Now())
+
+			version, installed, err := InstallFromSource(gowrapHome, repository, testCase.ref)
+			require.NoError(t, err)
+			assert.True(t, installed)
+			assert.Equal(t, testCase.expectedVersion, version)
+
+			versionDir := filepath.Join(gowrapHome, "versions", version)
+			assert.FileExists(t, filepath.Join(versionDir, "bin", "go"))
+			_, readmeErr := os.Stat(filepath.Join(versionDir, "README"))
+			assert.Equal(t, len(testCase.ref) > 0, os.IsNotExist(readmeErr))
+
+			env, err := ioutil.ReadFile(filepath.Join(versionDir, "build.env"))
+			require.NoError(t, err)
+			environ := strings.Split(strings.TrimSpace(string(env)), "\n")
+			assert.Contains(t, environ, goRootBootstrapEnvVar+"="+filepath.Join(gowrapHome, "versions", "1.21.5"))
+			assert.NotContains(t, environ, goRootBootstrapEnvVar+"=/usr/local/go")
+			assert.NotContains(t, environ, "GOROOT=/usr/local/go")
+
+			version, installed, err = InstallFromSource(gowrapHome, repository, testCase.ref)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedVersion, version)
+			assert.Equal(t, version == semver.Tip, installed)
+		})
+	}
+}
+
+func Test_InstallFromSource_WithoutBootstrapVersion(t *testing.T) {
+	skipWithoutGit(t)
+
+	_, _, err := InstallFromSource(t.TempDir(), createGoRepository(t), "")
+	assert.Error(t, err)
+}
+
+func Test_InstallFromSource_UnknownRef(t *testing.T) {
+	skipWithoutGit(t)
+
+	gowrapHome := t.TempDir()
+	versionstest.CreateInstalledVersion(t, gowrapHome, "1.21.5", time.Now())
+
+	_, _, err := InstallFromSource(gowrapHome, createGoRepository(t), "unknown")
+	assert.Error(t, err)
+}
+
+func Test_develVersionFor(t *testing.T) {
+	skipWithoutGit(t)
+
+	repository := createGoRepository(t)
+	commit := gitOutput(t, repository, "rev-parse", "HEAD")
+
+	testCases := map[string]struct {
+		ref      string
+		expected string
+	}{
+		"NoRef": {
+			ref:      "",
+			expected: semver.Tip,
+		},
+		"Ref": {
+			ref:      "HEAD",
+			expected: develVersionPrefix + commit[:develCommitLength],
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			actual, err := develVersionFor(repository, testCase.ref)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, actual)
+			assert.True(t, semver.IsDevel(actual))
+		})
+	}
+}
+
+func Test_runGit(t *testing.T) {
+	skipWithoutGit(t)
+
+	repository := createGoRepository(t)
+	assert.NoError(t, runGit(repository, "status", "--short"))
+	assert.Error(t, runGit(repository, "checkout", "--quiet", "unknown"))
+}
+
+func Test_withoutGoRoot(t *testing.T) {
+	environ := []string{
+		"PATH=/usr/bin",
+		"GOROOT=/usr/local/go",
+		goRootBootstrapEnvVar + "=/usr/local/go",
+		"GOROOTS=/kept",
+		"GOPATH=/home/user/go",
+	}
+
+	expected := []string{"PATH=/usr/bin", "GOROOTS=/kept", "GOPATH=/home/user/go"}
+	assert.Equal(t, expected, withoutGoRoot(environ))
+}
+
+func skipWithoutGit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the go build is faked with a shell script")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+}
+
+// createGoRepository creates a local git repository with a fake go source
+// tree in a single commit.
+func createGoRepository(t *testing.T) string {
+	repository := t.TempDir()
+	gitOutput(t, repository, "init", "--quiet")
+	require.NoError(t, os.MkdirAll(filepath.Join(repository, "src"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(repository, "src", "make.bash"), []byte(fakeMakeScript), 0755))
+	gitOutput(t, repository, "add", "-A")
+	gitOutput(t, repository, "commit", "--quiet", "-m", "fake go source")
+	return repository
+}
+
+func commitFile(t *testing.T, repository, name, content string) {
+	require.NoError(t, ioutil.WriteFile(filepath.Join(repository, name), []byte(content), 0644))
+	gitOutput(t, repository, "add", "-A")
+	gitOutput(t, repository, "commit", "--quiet", "-m", "add "+name)
+}
+
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	require.NoError(t, cmd.Run(), stderr.String())
+	return strings.TrimSpace(stdout.String())
+}
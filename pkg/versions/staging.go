@@ -0,0 +1,95 @@
+package versions
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xabierlaiseca/gowrap/pkg/util/lock"
+)
+
+const (
+	locksDir   = "locks"
+	stagingDir = "staging"
+
+	staleStagingAge = 24 * time.Hour
+)
+
+// lockVersion acquires the lock of the given version, so only one process
+// installs or uninstalls it at a time.
+func lockVersion(gowrapHome, version string) (*lock.Lock, error) {
+	return lock.Acquire(filepath.Join(gowrapHome, locksDir, version+".lock"))
+}
+
+// newStagingDir creates a directory to prepare installs in. It lives in gowrap
+// home so prepared installs can be atomically renamed into the versions
+// directory. Staging directories left behind by interrupted installs are
+// removed.
+func newStagingDir(gowrapHome string) (string, error) {
+	dir := filepath.Join(gowrapHome, stagingDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	removeStaleStagingDirs(dir)
+	return ioutil.TempDir(dir, "install-")
+}
+
+func removeStaleStagingDirs(dir string) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		logrus.Debugf("failed to list staging directories: %v", err)
+		return
+	}
+
+	for _, entry := range entries {
+		if time.Since(entry.ModTime()) > staleStagingAge {
+			if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+				logrus.Debugf("failed to remove stale staging directory: %v", err)
+			}
+		}
+	}
+}
+
+// installGoRoot moves a prepared go root into the versions directory like
+// moveIntoVersions does, recording the manifest of its files. The caller must
+// hold the lock of the version.
+func installGoRoot(gowrapHome, versionsDir, version, goRoot string) error {
+	m, err := buildManifest(goRoot, manifestAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	if err := moveIntoVersions(versionsDir, version, goRoot); err != nil {
+		return err
+	}
+
+	return storeManifest(gowrapHome, version, m)
+}
+
+// moveIntoVersions atomically moves a prepared go root into the versions
+// directory, replacing any previous install of the version. The caller must
+// hold the lock of the version.
+func moveIntoVersions(versionsDir, version, goRoot string) error {
+	destinationDir := filepath.Join(versionsDir, version)
+	if _, err := os.Stat(destinationDir); err == nil {
+		replacedDir := goRoot + ".replaced"
+		if err := os.Rename(destinationDir, replacedDir); err != nil {
+			return err
+		}
+		defer os.RemoveAll(replacedDir)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.Rename(goRoot, destinationDir); err != nil {
+		return err
+	}
+
+	// archives keep the modification time of the release, while it is used to
+	// know when the version was installed
+	now := time.Now()
+	return os.Chtimes(destinationDir, now, now)
+}
@@ -0,0 +1,84 @@
+package versions
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/mholt/archiver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InstallFromArchive_Concurrently(t *testing.T) {
+	// no versions file available, so checksums are not verified
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	t.Setenv("GOWRAP_MIRROR", server.URL)
+	t.Setenv("GOWRAP_CACHE_DIR", t.TempDir())
+
+	gowrapHome := t.TempDir()
+	archivePath := createGoArchive(t, "go1.21.5")
+
+	var installs int
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			version, installed, err := InstallFromArchive(gowrapHome, archivePath, "")
+			assert.NoError(t, err)
+			assert.Equal(t, "1.21.5", version)
+
+			if installed {
+				mutex.Lock()
+				installs++
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, installs)
+	installed, err := ListInstalled(gowrapHome)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.21.5"}, installed)
+	assert.FileExists(t, filepath.Join(gowrapHome, "versions", "1.21.5", "bin", "go"))
+}
+
+func Test_MoveIntoVersions_ReplacesPreviousInstall(t *testing.T) {
+	versionsDir := t.TempDir()
+	stagingDir := t.TempDir()
+
+	previous := filepath.Join(versionsDir, "tip")
+	require.NoError(t, os.MkdirAll(previous, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(previous, "old"), nil, 0600))
+
+	goRoot := filepath.Join(stagingDir, "go")
+	require.NoError(t, os.MkdirAll(goRoot, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(goRoot, "new"), nil, 0600))
+
+	require.NoError(t, moveIntoVersions(versionsDir, "tip", goRoot))
+	assert.FileExists(t, filepath.Join(versionsDir, "tip", "new"))
+	assert.NoFileExists(t, filepath.Join(versionsDir, "tip", "old"))
+	assert.NoDirExists(t, goRoot)
+}
+
+// createGoArchive creates an archive with a fake go distribution of the given
+// version.
+func createGoArchive(t *testing.T, version string) string {
+	dir := t.TempDir()
+	goRoot := filepath.Join(dir, "go")
+	require.NoError(t, os.MkdirAll(filepath.Join(goRoot, "bin"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(goRoot, "bin", "go"), []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(goRoot, goVersionFile), []byte(version+"\n"), 0600))
+
+	archivePath := filepath.Join(dir, version+".tar.gz")
+	require.NoError(t, archiver.Archive([]string{goRoot}, archivePath))
+	return archivePath
+}
@@ -0,0 +1,78 @@
+package versions
+
+import (
+	"github.com/xabierlaiseca/gowrap/pkg/semver"
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+// Upgrade is the latest available version for a prefix and the installed
+// versions of the same minor line it supersedes.
+type Upgrade struct {
+	Prefix     string
+	Latest     string
+	Superseded []string
+}
+
+// FindUpgrades returns the latest available version for each of the given
+// prefixes, or for every installed minor line if no prefixes are given.
+// Installed minor lines without released versions available are skipped.
+func FindUpgrades(gowrapHome string, prefixes ...string) ([]Upgrade, error) {
+	installed, err := ListInstalled(gowrapHome)
+	if err != nil {
+		return nil, err
+	}
+
+	explicit := len(prefixes) > 0
+	if !explicit {
+		if prefixes, err = installedMinors(installed); err != nil {
+			return nil, err
+		}
+	}
+
+	upgrades := make([]Upgrade, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		latest, err := FindLatestAvailable(gowrapHome, prefix)
+		if customerrors.IsNotFound(err) && !explicit {
+			continue
+		} else if customerrors.IsNotFound(err) {
+			return nil, customerrors.Errorf("no versions available for go %s", prefix)
+		} else if err != nil {
+			return nil, err
+		}
+
+		upgrade := Upgrade{Prefix: prefix, Latest: latest}
+		for _, version := range installed {
+			if IsSupersededBy(version, latest) {
+				upgrade.Superseded = append(upgrade.Superseded, version)
+			}
+		}
+
+		upgrades = append(upgrades, upgrade)
+	}
+
+	return upgrades, nil
+}
+
+// IsSupersededBy returns true if version belongs to the same minor line as
+// latest and is older than it.
+func IsSupersededBy(version, latest string) bool {
+	return !semver.IsDevel(version) &&
+		semver.Minor(version) == semver.Minor(latest) &&
+		semver.IsLessThan(version, latest)
+}
+
+// installedMinors returns the minor lines of the given installed versions,
+// sorted by version. Development versions don't belong to any line.
+func installedMinors(installed []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var minors []string
+	for _, version := range installed {
+		minor := semver.Minor(version)
+		if !semver.IsDevel(version) && !seen[minor] {
+			seen[minor] = true
+			minors = append(minors, minor)
+		}
+	}
+
+	return minors, sortVersions(minors)
+}
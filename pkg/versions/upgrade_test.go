@@ -0,0 +1,104 @@
+package versions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/versions/versionstest"
+	"github.com/xabierlaiseca/gowrap/pkg/versionsfile"
+)
+
+func Test_IsSupersededBy(t *testing.T) {
+	testCases := map[string]struct {
+		version  string
+		latest   string
+		expected bool
+	}{
+		"OlderPatch":               {version: "1.21.4", latest: "1.21.5", expected: true},
+		"SamePatch":                {version: "1.21.5", latest: "1.21.5", expected: false},
+		"NewerPatch":               {version: "1.21.6", latest: "1.21.5", expected: false},
+		"OtherMinor":               {version: "1.20.3", latest: "1.21.5", expected: false},
+		"PreReleaseOfMinor":        {version: "1.21rc2", latest: "1.21.5", expected: true},
+		"PreReleaseOfMinorRelease": {version: "1.20rc1", latest: "1.20", expected: true},
+		"DevelVersion":             {version: "devel-8e4a6a4c1b2d", latest: "1.21.5", expected: false},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, IsSupersededBy(testCase.version, testCase.latest))
+		})
+	}
+}
+
+func Test_FindUpgrades(t *testing.T) {
+	archives := make(map[string]versionsfile.GoArchive)
+	for _, version := range []string{"1.20.13", "1.20.14", "1.21.8", "1.22rc1", "1.22.0", "1.22.1"} {
+		archives[version] = versionsfile.GoArchive{URL: "https://go.dev/dl/go" + version + ".linux-amd64.tar.gz"}
+	}
+	writeAvailable(t, archives)
+
+	testCases := map[string]struct {
+		installed []string
+		prefixes  []string
+
+		expected      []Upgrade
+		expectedError bool
+	}{
+		"InstalledMinors": {
+			installed: []string{"1.20.13", "1.21.5", "1.21.8", "1.22rc1", "tip"},
+			expected: []Upgrade{
+				{Prefix: "1.20", Latest: "1.20.14", Superseded: []string{"1.20.13"}},
+				{Prefix: "1.21", Latest: "1.21.8", Superseded: []string{"1.21.5"}},
+				{Prefix: "1.22", Latest: "1.22.1", Superseded: []string{"1.22rc1"}},
+			},
+		},
+		"InstalledMinorWithoutAvailableVersionsIsSkipped": {
+			installed: []string{"1.19.2", "1.21.5"},
+			expected: []Upgrade{
+				{Prefix: "1.21", Latest: "1.21.8", Superseded: []string{"1.21.5"}},
+			},
+		},
+		"NoInstalledVersions": {
+			expected: []Upgrade{},
+		},
+		"ExplicitPrefixes": {
+			installed: []string{"1.20.13", "1.21.5"},
+			prefixes:  []string{"1.22", "1.20"},
+			expected: []Upgrade{
+				{Prefix: "1.22", Latest: "1.22.1"},
+				{Prefix: "1.20", Latest: "1.20.14", Superseded: []string{"1.20.13"}},
+			},
+		},
+		"ExplicitPrefixWithoutAvailableVersions": {
+			installed:     []string{"1.21.5"},
+			prefixes:      []string{"1.19"},
+			expectedError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			gowrapHome := t.TempDir()
+			for _, version := range testCase.installed {
+				versionstest.CreateInstalledVersion(t, gowrapHome, version, time.Now())
+			}
+
+			actual, err := FindUpgrades(gowrapHome, testCase.prefixes...)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func Test_InstalledMinors(t *testing.T) {
+	minors, err := installedMinors([]string{"1.21.5", "1.9.2", "1.21.4", "tip", "1.22rc1", "1.20.3"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.9", "1.20", "1.21", "1.22"}, minors)
+}
@@ -0,0 +1,99 @@
+package versions
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	usageDir = "usage"
+
+	// usageRecordInterval is the minimum time between records of the usage of
+	// a version from the same project, to keep recording cheap.
+	usageRecordInterval = time.Hour
+)
+
+// Usage is the last usage of an installed version.
+type Usage struct {
+	LastUsed time.Time `json:"lastUsed"`
+	// ProjectRoot is the root of the project or workspace the version was
+	// used from, empty if used outside projects.
+	ProjectRoot string `json:"projectRoot,omitempty"`
+}
+
+// RecordUsage records that the given version was used from the given project
+// root. Records are skipped if the version was recently used from the same
+// project root.
+func RecordUsage(gowrapHome, version, projectRoot string) error {
+	usage, err := GetUsage(gowrapHome, version)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if usage != nil && usage.ProjectRoot == projectRoot && now.Sub(usage.LastUsed) < usageRecordInterval {
+		return nil
+	}
+
+	content, err := json.Marshal(&Usage{LastUsed: now, ProjectRoot: projectRoot})
+	if err != nil {
+		return err
+	}
+
+	usagePath := buildUsagePath(gowrapHome, version)
+	if err := os.MkdirAll(filepath.Dir(usagePath), 0755); err != nil {
+		return err
+	}
+
+	// written to a temporary file first, so concurrent readers never see a
+	// partially written record
+	f, err := ioutil.TempFile(filepath.Dir(usagePath), version+".json.")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), usagePath)
+}
+
+// GetUsage returns the last usage of the given version, or nil if its usage was
+// never recorded.
+func GetUsage(gowrapHome, version string) (*Usage, error) {
+	content, err := ioutil.ReadFile(buildUsagePath(gowrapHome, version))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	usage := &Usage{}
+	if err := json.Unmarshal(content, usage); err != nil {
+		// a corrupted record is replaced by the next one
+		return nil, nil
+	}
+
+	return usage, nil
+}
+
+func removeUsage(gowrapHome, version string) error {
+	if err := os.Remove(buildUsagePath(gowrapHome, version)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func buildUsagePath(gowrapHome, version string) string {
+	return filepath.Join(gowrapHome, usageDir, version+".json")
+}
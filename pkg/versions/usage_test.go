@@ -0,0 +1,105 @@
+package versions
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabierlaiseca/gowrap/pkg/versions/versionstest"
+)
+
+func Test_RecordUsage(t *testing.T) {
+	testCases := map[string]struct {
+		previous            *Usage
+		projectRoot         string
+		expectedProjectRoot string
+		expectedRecorded    bool
+	}{
+		"NeverUsed": {
+			projectRoot:         "/projects/a",
+			expectedProjectRoot: "/projects/a",
+			expectedRecorded:    true,
+		},
+		"RecentlyUsedFromSameProject": {
+			previous:            &Usage{LastUsed: time.Now().Add(-time.Minute), ProjectRoot: "/projects/a"},
+			projectRoot:         "/projects/a",
+			expectedProjectRoot: "/projects/a",
+			expectedRecorded:    false,
+		},
+		"RecentlyUsedFromOtherProject": {
+			previous:            &Usage{LastUsed: time.Now().Add(-time.Minute), ProjectRoot: "/projects/a"},
+			projectRoot:         "/projects/b",
+			expectedProjectRoot: "/projects/b",
+			expectedRecorded:    true,
+		},
+		"UsedLongAgoFromSameProject": {
+			previous:            &Usage{LastUsed: time.Now().Add(-2 * usageRecordInterval), ProjectRoot: "/projects/a"},
+			projectRoot:         "/projects/a",
+			expectedProjectRoot: "/projects/a",
+			expectedRecorded:    true,
+		},
+		"UsedOutsideProjects": {
+			previous:            &Usage{LastUsed: time.Now().Add(-time.Minute), ProjectRoot: "/projects/a"},
+			projectRoot:         "",
+			expectedProjectRoot: "",
+			expectedRecorded:    true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			gowrapHome := t.TempDir()
+
+			if testCase.previous != nil {
+				writeUsage(t, gowrapHome, "1.21.5", testCase.previous)
+			}
+
+			before := time.Now()
+			require.NoError(t, RecordUsage(gowrapHome, "1.21.5", testCase.projectRoot))
+
+			usage, err := GetUsage(gowrapHome, "1.21.5")
+			require.NoError(t, err)
+			require.NotNil(t, usage)
+			assert.Equal(t, testCase.expectedProjectRoot, usage.ProjectRoot)
+			assert.Equal(t, testCase.expectedRecorded, !usage.LastUsed.Before(before))
+		})
+	}
+}
+
+func Test_LastUsed_PrefersRecordedUsage(t *testing.T) {
+	gowrapHome := t.TempDir()
+
+	versionstest.CreateInstalledVersion(t, gowrapHome, "1.21.5", time.Now())
+	lastUsed := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	writeUsage(t, gowrapHome, "1.21.5", &Usage{LastUsed: lastUsed})
+
+	actual, err := LastUsed(gowrapHome, "1.21.5")
+	require.NoError(t, err)
+	assert.True(t, lastUsed.Equal(actual))
+}
+
+func Test_Uninstall_RemovesUsage(t *testing.T) {
+	gowrapHome := t.TempDir()
+
+	versionstest.CreateInstalledVersion(t, gowrapHome, "1.21.5", time.Now())
+	writeUsage(t, gowrapHome, "1.21.5", &Usage{LastUsed: time.Now()})
+
+	require.NoError(t, Uninstall(gowrapHome, "1.21.5"))
+	assert.NoFileExists(t, buildUsagePath(gowrapHome, "1.21.5"))
+
+	usage, err := GetUsage(gowrapHome, "1.21.5")
+	require.NoError(t, err)
+	assert.Nil(t, usage)
+}
+
+func writeUsage(t *testing.T, gowrapHome, version string, usage *Usage) {
+	content, err := json.Marshal(usage)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(buildUsagePath(gowrapHome, version)), 0755))
+	require.NoError(t, ioutil.WriteFile(buildUsagePath(gowrapHome, version), content, 0644))
+}
@@ -0,0 +1,22 @@
+// Package versionstest provides fixtures for tests working with installed Go
+// versions.
+package versionstest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// CreateInstalledVersion creates a fake installation of the given version in
+// gowrap home, last used at the given time.
+func CreateInstalledVersion(t *testing.T, gowrapHome, version string, lastUsed time.Time) {
+	versionDir := filepath.Join(gowrapHome, "versions", version)
+	require.NoError(t, os.MkdirAll(filepath.Join(versionDir, "bin"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(versionDir, "bin", "go"), []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.Chtimes(versionDir, lastUsed, lastUsed))
+}
@@ -3,7 +3,9 @@ package versionsfile
 import (
 	"encoding/json"
 	"fmt"
+	"path"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -21,7 +23,20 @@ type GoArchive struct {
 
 const oneDay = 24 * time.Hour
 
-func Load() (map[string]GoArchive, error) {
+// Options defines where and how the versions file is downloaded.
+type Options struct {
+	// Mirror is the base URL to download the versions file and go archives
+	// from, default locations are used if empty.
+	Mirror string
+	// SkipSignatureVerification disables the verification of the versions
+	// file signature.
+	SkipSignatureVerification bool
+}
+
+// Load returns the go archives for the current platform indexed by version,
+// downloading the versions file if not cached. If a mirror is provided, the
+// versions file is downloaded from it and the archives point to it.
+func Load(options Options) (map[string]GoArchive, error) {
 	content, err := cache.Get(localVersionsCachedFile)
 	if err != nil {
 		logrus.Warningf("failed to get cached go versions file: %v", err)
@@ -30,14 +45,17 @@ func Load() (map[string]GoArchive, error) {
 	if content != nil {
 		archivesForPlatform := make(map[string]GoArchive)
 		err = json.Unmarshal(content, &archivesForPlatform)
-		return archivesForPlatform, err
+		return withMirror(archivesForPlatform, options.Mirror), err
 	}
 
-	return DownloadToCache()
+	return DownloadToCache(options)
 }
 
-func DownloadToCache() (map[string]GoArchive, error) {
-	rvf, err := download()
+// DownloadToCache downloads the versions file and caches the go archives for
+// the current platform. The signature of the versions file is verified before
+// caching it, unless disabled in the given options.
+func DownloadToCache(options Options) (map[string]GoArchive, error) {
+	rvf, err := download(versionsFileURLFor(options.Mirror), !options.SkipSignatureVerification)
 	if err != nil {
 		return nil, err
 	}
@@ -60,5 +78,25 @@ func DownloadToCache() (map[string]GoArchive, error) {
 		logrus.Warningf("failed to serialise archives for caching: %v", err)
 	}
 
-	return archivesForPlatform, err
+	return withMirror(archivesForPlatform, options.Mirror), err
+}
+
+// withMirror returns the archives with their URLs pointing to the mirror.
+// Archives are cached without the mirror, so configuration changes apply
+// immediately.
+func withMirror(archives map[string]GoArchive, mirror string) map[string]GoArchive {
+	if len(mirror) == 0 {
+		return archives
+	}
+
+	for version, archive := range archives {
+		archive.URL = mirrorURL(mirror, path.Base(archive.URL))
+		archives[version] = archive
+	}
+
+	return archives
+}
+
+func mirrorURL(mirror, filename string) string {
+	return strings.TrimSuffix(mirror, "/") + "/" + filename
 }
@@ -0,0 +1,50 @@
+package versionsfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithMirror(t *testing.T) {
+	archives := func() map[string]GoArchive {
+		return map[string]GoArchive{
+			"1.21.5": {
+				URL:               "https://dl.google.com/go/go1.21.5.linux-amd64.tar.gz",
+				Checksum:          "checksum",
+				ChecksumAlgorithm: "SHA256",
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		mirror      string
+		expectedURL string
+	}{
+		"NoMirror": {
+			mirror:      "",
+			expectedURL: "https://dl.google.com/go/go1.21.5.linux-amd64.tar.gz",
+		},
+		"Mirror": {
+			mirror:      "https://mirror.example.com/golang",
+			expectedURL: "https://mirror.example.com/golang/go1.21.5.linux-amd64.tar.gz",
+		},
+		"MirrorWithTrailingSlash": {
+			mirror:      "https://mirror.example.com/golang/",
+			expectedURL: "https://mirror.example.com/golang/go1.21.5.linux-amd64.tar.gz",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			actual := withMirror(archives(), testCase.mirror)
+			assert.Equal(t, testCase.expectedURL, actual["1.21.5"].URL)
+			assert.Equal(t, "checksum", actual["1.21.5"].Checksum)
+		})
+	}
+}
+
+func Test_VersionsFileURLFor(t *testing.T) {
+	assert.Equal(t, versionsFileURL, versionsFileURLFor(""))
+	assert.Equal(t, "https://mirror.example.com/golang/versions.json", versionsFileURLFor("https://mirror.example.com/golang/"))
+}
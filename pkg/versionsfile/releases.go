@@ -0,0 +1,95 @@
+package versionsfile
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+	httputils "github.com/xabierlaiseca/gowrap/pkg/util/http"
+)
+
+const (
+	goDevWebsite    = "https://go.dev"
+	releasesBaseURL = goDevWebsite + "/dl/"
+	releasesURL     = releasesBaseURL + "?mode=json&include=all"
+
+	archiveKind = "archive"
+)
+
+// release is a Go release as published by the go.dev downloads JSON API.
+type release struct {
+	Version string        `json:"version"`
+	Stable  bool          `json:"stable"`
+	Files   []releaseFile `json:"files"`
+}
+
+type releaseFile struct {
+	Filename string `json:"filename"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Version  string `json:"version"`
+	SHA256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+	Kind     string `json:"kind"`
+}
+
+func getReleases(url string) (*remoteVersionsFile, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	response, err := httputils.Get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, customerrors.Errorf("unexpected status code (%d) while getting releases", response.StatusCode)
+	}
+
+	var releases []release
+	if err := json.NewDecoder(response.Body).Decode(&releases); err != nil {
+		return nil, err
+	}
+
+	rvf := extractReleasesVersionsFile(releases)
+	if len(rvf.versions) == 0 {
+		return nil, customerrors.Error("no valid go versions found in releases")
+	}
+
+	return &rvf, nil
+}
+
+func extractReleasesVersionsFile(releases []release) remoteVersionsFile {
+	versions := make(map[string][]platformGoArchive)
+	for _, r := range releases {
+		if !validGoVersionRegex.MatchString(r.Version) {
+			continue
+		}
+
+		var archives []platformGoArchive
+		for _, f := range r.Files {
+			if f.Kind != archiveKind || len(f.OS) == 0 || len(f.Arch) == 0 || len(f.SHA256) == 0 {
+				continue
+			}
+
+			archives = append(archives, platformGoArchive{
+				GoArchive: GoArchive{
+					URL:               releasesBaseURL + f.Filename,
+					Checksum:          f.SHA256,
+					ChecksumAlgorithm: "SHA256",
+				},
+				ARCH: f.Arch,
+				OS:   f.OS,
+			})
+		}
+
+		if len(archives) > 0 {
+			versions[strings.TrimPrefix(r.Version, "go")] = archives
+		}
+	}
+
+	return remoteVersionsFile{versions: versions}
+}
@@ -0,0 +1,76 @@
+package versionsfile
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Generate(t *testing.T) {
+	testCases := map[string]struct {
+		releasesFixture  string
+		expectedVersions map[string][]platformGoArchive
+	}{
+		"FromReleases": {
+			releasesFixture: "testdata/releases.json",
+			expectedVersions: map[string][]platformGoArchive{
+				"1.22rc1": {
+					{
+						GoArchive: GoArchive{URL: "https://go.dev/dl/go1.22rc1.linux-amd64.tar.gz", Checksum: "d8d8f6a3d6d9e2c7d9f0b0b4b2a4b7b8e2f1b7a0c5f8d3c9e4a1f6b2c7d8e9f0", ChecksumAlgorithm: "SHA256"},
+						OS:        "linux",
+						ARCH:      "amd64",
+					},
+				},
+				"1.21.6": {
+					{
+						GoArchive: GoArchive{URL: "https://go.dev/dl/go1.21.6.darwin-arm64.tar.gz", Checksum: "0ff4b5ff0b5a4e8e1b8a4cf2a5a4c2e71f8e3f8d3c7b1a2d4e6f8a9b0c1d2e3f", ChecksumAlgorithm: "SHA256"},
+						OS:        "darwin",
+						ARCH:      "arm64",
+					},
+					{
+						GoArchive: GoArchive{URL: "https://go.dev/dl/go1.21.6.linux-amd64.tar.gz", Checksum: "3f934f40ac360b9c01f616a9aa1796d227d8b0328bf64cb045c7b8c4ee9caea4", ChecksumAlgorithm: "SHA256"},
+						OS:        "linux",
+						ARCH:      "amd64",
+					},
+				},
+			},
+		},
+		"FallbackToDownloadsPage": {
+			releasesFixture: "",
+			expectedVersions: map[string][]platformGoArchive{
+				"1.21.6": {
+					{
+						GoArchive: GoArchive{URL: "https://golang.org/dl/go1.21.6.linux-amd64.tar.gz", Checksum: "3f934f40ac360b9c01f616a9aa1796d227d8b0328bf64cb045c7b8c4ee9caea4", ChecksumAlgorithm: "SHA256"},
+						OS:        "linux",
+						ARCH:      "amd64",
+					},
+				},
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/releases", func(w http.ResponseWriter, r *http.Request) {
+				if len(testCase.releasesFixture) == 0 {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				http.ServeFile(w, r, testCase.releasesFixture)
+			})
+			mux.HandleFunc("/dl/", func(w http.ResponseWriter, r *http.Request) {
+				http.ServeFile(w, r, "testdata/downloads.html")
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			rvf, err := generate(server.URL+"/releases", server.URL+"/dl/")
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedVersions, rvf.versions)
+		})
+	}
+}
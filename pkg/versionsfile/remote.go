@@ -2,16 +2,19 @@ package versionsfile
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"path"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/sirupsen/logrus"
 	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
 	httputils "github.com/xabierlaiseca/gowrap/pkg/util/http"
 )
@@ -43,25 +46,36 @@ func (rvf *remoteVersionsFile) getArchivesFor(arch, os string) map[string]platfo
 	return foundArchives
 }
 
-const versionsFileURL = "https://raw.githubusercontent.com/xabierlaiseca/gowrap/master/data/versions.json"
+const (
+	versionsFileName = "versions.json"
+	versionsFileURL  = "https://raw.githubusercontent.com/xabierlaiseca/gowrap/master/data/" + versionsFileName
+)
 
-func download() (*remoteVersionsFile, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+func versionsFileURLFor(mirror string) string {
+	if len(mirror) == 0 {
+		return versionsFileURL
+	}
+
+	return mirrorURL(mirror, versionsFileName)
+}
 
-	response, err := httputils.Get(ctx, versionsFileURL)
+func download(url string, verifySignature bool) (*remoteVersionsFile, error) {
+	body, err := downloadFile(url)
 	if err != nil {
 		return nil, err
 	}
 
-	defer response.Body.Close()
-	if response.StatusCode != http.StatusOK {
-		return nil, customerrors.Errorf("failed downloading versions file, unexpected status: %d", response.StatusCode)
-	}
+	if key := embeddedPublicKey(); verifySignature && key == nil {
+		return nil, customerrors.Error("versions file signature can't be verified, no valid public key embedded in this build")
+	} else if verifySignature {
+		signature, err := downloadFile(url + signatureFileSuffix)
+		if err != nil {
+			return nil, customerrors.Errorf("failed downloading versions file signature: %v", err)
+		}
 
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
+		if err := verify(body, signature, key); err != nil {
+			return nil, err
+		}
 	}
 
 	versions := make(map[string][]platformGoArchive)
@@ -77,29 +91,77 @@ func download() (*remoteVersionsFile, error) {
 	return &rvf, nil
 }
 
-func Generate(outputPath string) error {
-	downloadsPageDoc, err := getDownloadsPage()
+func downloadFile(url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	response, err := httputils.Get(ctx, url)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	rvf := extractRemoteVersionsFile(downloadsPageDoc)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, customerrors.Errorf("failed downloading %s, unexpected status: %d", path.Base(url), response.StatusCode)
+	}
+
+	return ioutil.ReadAll(response.Body)
+}
+
+// Generate writes the versions file to the given path. Versions are taken from
+// the go.dev releases JSON API, falling back to scraping the downloads page if
+// the API is not available. If a signing key is provided, a detached signature
+// is written next to the versions file.
+func Generate(outputPath string, signingKey ed25519.PrivateKey) error {
+	rvf, err := generate(releasesURL, downloadsPageURL)
+	if err != nil {
+		return err
+	}
 
 	versionsBytes, err := json.MarshalIndent(rvf.versions, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return ioutil.WriteFile(outputPath, versionsBytes, 0600)
+	if err := ioutil.WriteFile(outputPath, versionsBytes, 0600); err != nil {
+		return err
+	}
+
+	signaturePath := outputPath + signatureFileSuffix
+	if signingKey == nil {
+		// a previous signature would not match the new versions file
+		if err := os.Remove(signaturePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	return ioutil.WriteFile(signaturePath, sign(versionsBytes, signingKey), 0600)
+}
+
+func generate(releasesURL, downloadsPageURL string) (*remoteVersionsFile, error) {
+	rvf, err := getReleases(releasesURL)
+	if err == nil {
+		return rvf, nil
+	}
+
+	logrus.Warningf("failed to get go releases, falling back to downloads page: %v", err)
+	downloadsPageDoc, err := getDownloadsPage(downloadsPageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	extracted := extractRemoteVersionsFile(downloadsPageDoc)
+	return &extracted, nil
 }
 
 const golangWebsite = "https://golang.org"
 const downloadsPageURL = golangWebsite + "/dl/"
 
-func getDownloadsPage() (*goquery.Document, error) {
+func getDownloadsPage(url string) (*goquery.Document, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
-	response, err := httputils.Get(ctx, downloadsPageURL)
+	response, err := httputils.Get(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +174,7 @@ func getDownloadsPage() (*goquery.Document, error) {
 	return goquery.NewDocumentFromReader(response.Body)
 }
 
-var validGoVersionRegex = regexp.MustCompile(`^go[0-9]+(\.[0-9]+){1,2}$`)
+var validGoVersionRegex = regexp.MustCompile(`^go[0-9]+(\.[0-9]+){1,2}$|^go[0-9]+\.[0-9]+(beta|rc)[0-9]+$`)
 
 func extractRemoteVersionsFile(doc *goquery.Document) remoteVersionsFile {
 	versions := make(map[string][]platformGoArchive)
@@ -132,7 +194,7 @@ func extractRemoteVersionsFile(doc *goquery.Document) remoteVersionsFile {
 	return remoteVersionsFile{versions: versions}
 }
 
-var archiveFileRegex = regexp.MustCompile(`^go(?:[0-9]+\.){2,3}([^-]+)-([^\.]+)\..*$`)
+var archiveFileRegex = regexp.MustCompile(`^go[0-9]+(?:\.[0-9]+){1,2}(?:(?:beta|rc)[0-9]+)?\.([^-]+)-([^\.]+)\..*$`)
 
 func extractArchives(versionSelection *goquery.Selection) []platformGoArchive {
 	checksumAlgorithmTitle := versionSelection.
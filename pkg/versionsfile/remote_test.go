@@ -2,9 +2,12 @@ package versionsfile
 
 import (
 	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -85,3 +88,48 @@ func Test_RemoteVersionsFile_GetArchivesFor(t *testing.T) {
 		})
 	}
 }
+
+func Test_ExtractRemoteVersionsFile(t *testing.T) {
+	page := `<html><body>
+<div id="go1.22rc1">
+  <table>
+    <thead><tr><th>File name</th><th>Kind</th><th>OS</th><th>Arch</th><th>Size</th><th>SHA256 Checksum</th></tr></thead>
+    <tbody>
+      <tr><td><a href="/dl/go1.22rc1.linux-amd64.tar.gz">go1.22rc1.linux-amd64.tar.gz</a></td><td>Archive</td><td>Linux</td><td>x86-64</td><td>66MB</td><td><tt>abc</tt></td></tr>
+    </tbody>
+  </table>
+</div>
+<div id="go1.21.6">
+  <table>
+    <thead><tr><th>File name</th><th>Kind</th><th>OS</th><th>Arch</th><th>Size</th><th>SHA256 Checksum</th></tr></thead>
+    <tbody>
+      <tr><td><a href="/dl/go1.21.6.darwin-arm64.tar.gz">go1.21.6.darwin-arm64.tar.gz</a></td><td>Archive</td><td>macOS</td><td>ARM64</td><td>64MB</td><td><tt>def</tt></td></tr>
+    </tbody>
+  </table>
+</div>
+<div id="gonotaversion"></div>
+</body></html>`
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	require.NoError(t, err)
+
+	expected := map[string][]platformGoArchive{
+		"1.22rc1": {
+			{
+				GoArchive: GoArchive{URL: "https://golang.org/dl/go1.22rc1.linux-amd64.tar.gz", Checksum: "abc", ChecksumAlgorithm: "SHA256"},
+				OS:        "linux",
+				ARCH:      "amd64",
+			},
+		},
+		"1.21.6": {
+			{
+				GoArchive: GoArchive{URL: "https://golang.org/dl/go1.21.6.darwin-arm64.tar.gz", Checksum: "def", ChecksumAlgorithm: "SHA256"},
+				OS:        "darwin",
+				ARCH:      "arm64",
+			},
+		},
+	}
+
+	actual := extractRemoteVersionsFile(doc)
+	assert.Equal(t, expected, actual.versions)
+}
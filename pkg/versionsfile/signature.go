@@ -0,0 +1,68 @@
+package versionsfile
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+
+	"github.com/xabierlaiseca/gowrap/pkg/util/customerrors"
+)
+
+const signatureFileSuffix = ".sig"
+
+// publicKey is the base64 encoded ed25519 public key used to verify the
+// signature of the versions file, signed by CI with the matching private key.
+// It can be replaced at build time with
+// -ldflags "-X ...versionsfile.publicKey=<key>".
+var publicKey = "e0LFd0Qb4Qs5PBpV59zZNX1s+Bfz9QaEjC1tSiiij74="
+
+// GenerateSigningKey generates a new key pair to sign versions files, returning
+// the base64 encoded seed of the private key and the base64 encoded public key.
+func GenerateSigningKey() (string, string, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(private.Seed()), base64.StdEncoding.EncodeToString(public), nil
+}
+
+// ParseSigningKey parses a base64 encoded ed25519 seed into the private key
+// used to sign versions files.
+func ParseSigningKey(encodedSeed string) (ed25519.PrivateKey, error) {
+	seed, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace([]byte(encodedSeed))))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, customerrors.Error("invalid signing key, it must be a base64 encoded ed25519 seed")
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+func sign(content []byte, privateKey ed25519.PrivateKey) []byte {
+	signature := ed25519.Sign(privateKey, content)
+	return []byte(base64.StdEncoding.EncodeToString(signature) + "\n")
+}
+
+func verify(content, encodedSignature []byte, key ed25519.PublicKey) error {
+	signature, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encodedSignature)))
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return customerrors.Error("invalid versions file signature")
+	}
+
+	if !ed25519.Verify(key, content, signature) {
+		return customerrors.Error("versions file signature verification failed, the file may have been tampered with")
+	}
+
+	return nil
+}
+
+// embeddedPublicKey returns the public key to verify versions files with, or
+// nil if no valid key is embedded.
+func embeddedPublicKey() ed25519.PublicKey {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil
+	}
+	return key
+}
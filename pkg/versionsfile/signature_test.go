@@ -0,0 +1,159 @@
+package versionsfile
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Verify(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	content := []byte(`{"1.21.6": []}`)
+
+	testCases := map[string]struct {
+		content       []byte
+		signature     []byte
+		expectedError bool
+	}{
+		"ValidSignature": {
+			content:   content,
+			signature: sign(content, privateKey),
+		},
+		"TamperedContent": {
+			content:       []byte(`{"1.21.7": []}`),
+			signature:     sign(content, privateKey),
+			expectedError: true,
+		},
+		"InvalidSignature": {
+			content:       content,
+			signature:     []byte("not a signature"),
+			expectedError: true,
+		},
+		"EmptySignature": {
+			content:       content,
+			signature:     []byte{},
+			expectedError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			err := verify(testCase.content, testCase.signature, publicKey)
+			if testCase.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ParseSigningKey(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	key, err := ParseSigningKey(base64.StdEncoding.EncodeToString(seed) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, ed25519.NewKeyFromSeed(seed), key)
+
+	_, err = ParseSigningKey("invalid")
+	assert.Error(t, err)
+}
+
+func Test_Download_VerifiesSignature(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	content := []byte(`{"1.21.6": [{"url": "https://go.dev/dl/go1.21.6.linux-amd64.tar.gz", "os": "linux", "arch": "amd64"}]}`)
+	signature := sign(content, private)
+
+	testCases := map[string]struct {
+		publicKey       string
+		content         []byte
+		signature       []byte
+		verifySignature bool
+		expectedError   bool
+	}{
+		"SignedFile": {
+			publicKey:       base64.StdEncoding.EncodeToString(public),
+			content:         content,
+			signature:       signature,
+			verifySignature: true,
+		},
+		"TamperedFile": {
+			publicKey:       base64.StdEncoding.EncodeToString(public),
+			content:         append([]byte(" "), content...),
+			signature:       signature,
+			verifySignature: true,
+			expectedError:   true,
+		},
+		"UnsignedFile": {
+			publicKey:       base64.StdEncoding.EncodeToString(public),
+			content:         content,
+			verifySignature: true,
+			expectedError:   true,
+		},
+		"UnsignedFileWithoutVerification": {
+			publicKey:       base64.StdEncoding.EncodeToString(public),
+			content:         content,
+			verifySignature: false,
+		},
+		"SignedFileWithoutEmbeddedKey": {
+			content:         content,
+			signature:       signature,
+			verifySignature: true,
+			expectedError:   true,
+		},
+		"UnsignedFileWithoutEmbeddedKeyNorVerification": {
+			content:         content,
+			verifySignature: false,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			embeddedKey := publicKey
+			publicKey = testCase.publicKey
+			defer func() { publicKey = embeddedKey }()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/"+versionsFileName, func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write(testCase.content)
+			})
+			mux.HandleFunc("/"+versionsFileName+signatureFileSuffix, func(w http.ResponseWriter, _ *http.Request) {
+				if testCase.signature == nil {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write(testCase.signature)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			rvf, err := download(versionsFileURLFor(server.URL), testCase.verifySignature)
+			if testCase.expectedError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NotEmpty(t, rvf.versions)
+			}
+		})
+	}
+}
+
+func Test_EmbeddedPublicKey_VerifiesVersionsFile(t *testing.T) {
+	content, err := ioutil.ReadFile(filepath.Join("..", "..", "data", versionsFileName))
+	require.NoError(t, err)
+	signature, err := ioutil.ReadFile(filepath.Join("..", "..", "data", versionsFileName+signatureFileSuffix))
+	require.NoError(t, err)
+
+	key := embeddedPublicKey()
+	require.NotNil(t, key)
+	assert.NoError(t, verify(content, signature, key))
+}